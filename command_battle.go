@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/IdrisovMarat/pokemon/internal/battle"
+	"github.com/IdrisovMarat/pokemon/internal/growth"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+// maxBattleMoves - сколько атакующих приемов берет в бой каждый покемон
+const maxBattleMoves = 4
+
+// maxMoveLookups - сколько приемов из списка покемона просматривается,
+// чтобы найти атакующие (каждый прием - отдельный запрос к PokeAPI)
+const maxMoveLookups = 12
+
+// commandBattle проводит бой двух покемонов команды или, если соперник
+// не указан, бой с диким покемоном из последней встречи walk.
+// Без аргументов с диким покемоном сражается первый в команде.
+// Победивший пойманный покемон получает опыт
+func commandBattle(cfg *config, args ...string) error {
+	_, rest := parseOptions(args)
+	if len(rest) < 2 && cfg.wild == nil {
+		return errors.New("usage: battle <mine> <opponent>, or battle [mine] after walk finds a wild pokemon")
+	}
+
+	refs := rest[:min(len(rest), 2)]
+	if len(refs) == 0 {
+		refs = []string{""}
+	}
+	var owned []pokecache.Pokemonmain
+	for _, ref := range refs {
+		pokemon, err := partyMember(cfg, ref)
+		if err != nil {
+			return err
+		}
+		owned = append(owned, pokemon)
+	}
+	if len(owned) == 2 && owned[0].ID == owned[1].ID {
+		return fmt.Errorf("%s cannot battle itself", owned[0].DisplayName())
+	}
+
+	// Противник - второй пойманный покемон или дикий из встречи
+	var opponent pokecache.Pokemonmain
+	wild := len(owned) == 1
+	if wild {
+		p, err := cfg.client.GetPokemon(cfg.wild.Pokemon)
+		if err != nil {
+			return err
+		}
+		opponent = toPokedexEntry(p)
+		opponent.Level = cfg.wild.Level
+	} else {
+		opponent = owned[1]
+	}
+
+	mine, err := newCombatant(cfg, owned[0])
+	if err != nil {
+		return err
+	}
+	other, err := newCombatant(cfg, opponent)
+	if err != nil {
+		return err
+	}
+	switch {
+	case wild:
+		other.Name = "wild " + other.Name
+		// Раненый в прошлом бою дикий покемон не восстанавливается
+		other.HP = cfg.wild.HP
+	case mine.Name == other.Name:
+		// Два экземпляра одного вида различаем по номеру
+		mine.Name = fmt.Sprintf("%s #%d", mine.Name, owned[0].ID)
+		other.Name = fmt.Sprintf("%s #%d", other.Name, owned[1].ID)
+	}
+
+	// Без таблицы типов бой все равно возможен, просто без эффективности
+	var effectiveness battle.Effectiveness
+	if chart, err := getTypeChart(cfg); err != nil {
+		fmt.Println(err, "- type effectiveness is ignored")
+	} else {
+		effectiveness = chart.Effectiveness
+	}
+
+	result := battle.Fight(mine, other, cfg.random(), effectiveness)
+	for _, line := range result.Log {
+		fmt.Println(line)
+	}
+
+	if wild {
+		// Оставшееся HP облегчает поимку, см. throwBall
+		cfg.wild.HP, cfg.wild.MaxHP = result.HP[1], result.MaxHP[1]
+	}
+
+	switch result.Winner {
+	case mine.Name:
+		if wild {
+			// Побежденный дикий покемон больше не встречается
+			cfg.wild = nil
+		}
+		return gainExperience(cfg, owned[0], growth.Gain(opponent.BaseExperience, other.Level, !wild))
+	case other.Name:
+		if wild {
+			return nil
+		}
+		return gainExperience(cfg, owned[1], growth.Gain(owned[0].BaseExperience, mine.Level, true))
+	}
+	return nil
+}
+
+// newCombatant собирает участника боя из записи Pokedex и приемов из PokeAPI
+func newCombatant(cfg *config, pokemon pokecache.Pokemonmain) (battle.Combatant, error) {
+	combatant := battle.Combatant{
+		Name:  pokemon.DisplayName(),
+		Level: pokemonLevel(pokemon),
+		Types: pokemon.Types,
+		Base:  baseStats(pokemon.Stats),
+	}
+
+	moveNames := pokemon.Moves
+	// В старых сохранениях приемов нет - берем их из PokeAPI
+	if len(moveNames) == 0 {
+		p, err := cfg.client.GetPokemon(pokemon.Name)
+		if err != nil {
+			return combatant, err
+		}
+		for _, m := range p.Moves {
+			moveNames = append(moveNames, m.Move.Name)
+		}
+	}
+
+	for i, name := range moveNames {
+		if i == maxMoveLookups || len(combatant.Moves) == maxBattleMoves {
+			break
+		}
+		move, err := cfg.client.GetMove(name)
+		if err != nil {
+			return combatant, err
+		}
+		// Приемы без урона в бою не используются
+		if move.Power == nil || *move.Power == 0 || move.DamageClass.Name == "status" {
+			continue
+		}
+		accuracy := 0
+		if move.Accuracy != nil {
+			accuracy = *move.Accuracy
+		}
+		combatant.Moves = append(combatant.Moves, battle.Move{
+			Name:        move.Name,
+			Type:        move.Type.Name,
+			DamageClass: move.DamageClass.Name,
+			Power:       *move.Power,
+			Accuracy:    accuracy,
+		})
+	}
+
+	return combatant, nil
+}
+
+// baseStats переводит характеристики из Pokedex в формат боя
+func baseStats(stats []pokecache.Stat) battle.Stats {
+	var base battle.Stats
+	for _, s := range stats {
+		switch s.Name {
+		case "hp":
+			base.HP = s.BaseStat
+		case "attack":
+			base.Attack = s.BaseStat
+		case "defense":
+			base.Defense = s.BaseStat
+		case "special-attack":
+			base.SpecialAttack = s.BaseStat
+		case "special-defense":
+			base.SpecialDefense = s.BaseStat
+		case "speed":
+			base.Speed = s.BaseStat
+		}
+	}
+	return base
+}
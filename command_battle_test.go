@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IdrisovMarat/pokemon/internal/encounter"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+// newBattleServer отдает приемы и типы для тестов боя
+func newBattleServer(t *testing.T) *httptest.Server {
+	resources := map[string]string{
+		"/type/":                    `{"count":2,"results":[{"name":"electric"},{"name":"water"}]}`,
+		"/type/electric":            `{"name":"electric","damage_relations":{"double_damage_to":[{"name":"water"}],"half_damage_to":[{"name":"electric"}]}}`,
+		"/type/water":               `{"name":"water","damage_relations":{"half_damage_to":[{"name":"water"}],"double_damage_from":[{"name":"electric"}]}}`,
+		"/move/thunderbolt":         `{"name":"thunderbolt","power":90,"accuracy":100,"type":{"name":"electric"},"damage_class":{"name":"special"}}`,
+		"/move/growl":               `{"name":"growl","power":null,"accuracy":100,"type":{"name":"normal"},"damage_class":{"name":"status"}}`,
+		"/move/water-gun":           `{"name":"water-gun","power":40,"accuracy":100,"type":{"name":"water"},"damage_class":{"name":"special"}}`,
+		"/pokemon-species/pikachu":  `{"name":"pikachu","growth_rate":{"name":"medium"}}`,
+		"/pokemon-species/squirtle": `{"name":"squirtle","growth_rate":{"name":"medium"}}`,
+		"/growth-rate/medium":       `{"name":"medium","levels":[{"level":5,"experience":125},{"level":6,"experience":216},{"level":7,"experience":343}]}`,
+		"/pokemon/squirtle":         `{"name":"squirtle","base_experience":63,"types":[{"type":{"name":"water"}}],"stats":[{"base_stat":44,"stat":{"name":"hp"}},{"base_stat":50,"stat":{"name":"special-attack"}},{"base_stat":64,"stat":{"name":"special-defense"}},{"base_stat":43,"stat":{"name":"speed"}}],"moves":[{"move":{"name":"water-gun"}}]}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := resources[r.URL.Path]
+		if !ok {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+func newBattlePokedex() *pokecache.Pokedex {
+	pokedex := pokecache.NewPokedex()
+	pokedex.Add(pokecache.Pokemonmain{
+		Name:           "pikachu",
+		BaseExperience: 112,
+		Types:          []string{"electric"},
+		Stats:          []pokecache.Stat{{Name: "hp", BaseStat: 35}, {Name: "special-attack", BaseStat: 50}, {Name: "special-defense", BaseStat: 50}, {Name: "speed", BaseStat: 90}},
+		Moves:          []string{"growl", "thunderbolt"},
+	})
+	pokedex.Add(pokecache.Pokemonmain{
+		Name:           "squirtle",
+		BaseExperience: 63,
+		Types:          []string{"water"},
+		Stats:          []pokecache.Stat{{Name: "hp", BaseStat: 44}, {Name: "special-attack", BaseStat: 50}, {Name: "special-defense", BaseStat: 64}, {Name: "speed", BaseStat: 43}},
+		Moves:          []string{"water-gun"},
+	})
+	return pokedex
+}
+
+func runBattle(cfg *config, args ...string) (string, error) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := commandBattle(cfg, args...)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String(), err
+}
+
+func TestCommandBattle(t *testing.T) {
+	server := newBattleServer(t)
+	defer server.Close()
+
+	cfg := &config{}
+	// Без кэша, чтобы в выводе обоих боев не было строк о кэше
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+	// Оба боя начинаются с одинакового Pokedex: победитель первого получает опыт
+	cfg.pokedex, cfg.rng = newBattlePokedex(), rand.New(rand.NewSource(42))
+	first, err := runBattle(cfg, "pikachu", "squirtle")
+	if err != nil {
+		t.Fatalf("commandBattle returned error: %v", err)
+	}
+	cfg.pokedex, cfg.rng = newBattlePokedex(), rand.New(rand.NewSource(42))
+	second, _ := runBattle(cfg, "pikachu", "squirtle")
+
+	if first != second {
+		t.Errorf("Expected identical battles for the same seed")
+	}
+	if strings.Contains(first, "used growl") {
+		t.Errorf("Expected status moves to be skipped, got: %s", first)
+	}
+	if !strings.Contains(first, "It's super effective!") {
+		t.Errorf("Expected the type chart to be used, got: %s", first)
+	}
+	if !strings.Contains(first, "pikachu used thunderbolt!") || !strings.Contains(first, "wins!") {
+		t.Errorf("Expected a play-by-play log, got: %s", first)
+	}
+	if !strings.Contains(first, "gained") {
+		t.Errorf("Expected the winner to gain experience, got: %s", first)
+	}
+
+	// Ошибка, а не вывод: по ней скрипт завершается с ненулевым кодом
+	if _, err := runBattle(cfg, "pikachu", "mewtwo"); err == nil || err.Error() != "you have not caught mewtwo" {
+		t.Errorf("Expected an error for an uncaught pokemon, got %v", err)
+	}
+}
+
+func TestBattleRejectsSamePokemon(t *testing.T) {
+	cfg := &config{pokedex: newBattlePokedex(), rng: rand.New(rand.NewSource(42))}
+
+	pikachu, _ := cfg.pokedex.Get("pikachu")
+	// Ни запросов к PokeAPI, ни опыта: бой не начинается
+	for _, args := range [][]string{{"pikachu", "pikachu"}, {"#1", "pikachu"}} {
+		output, err := runBattle(cfg, args...)
+		if err == nil || !strings.Contains(err.Error(), "pikachu cannot battle itself") {
+			t.Errorf("battle %v: expected a self-battle error, got: %s (%v)", args, output, err)
+		}
+	}
+	if after, _ := cfg.pokedex.Get("pikachu"); after.Experience != pikachu.Experience {
+		t.Errorf("Expected no experience for a self-battle, got %d", after.Experience)
+	}
+}
+
+func TestBattleWildEncounterGivesExperience(t *testing.T) {
+	server := newBattleServer(t)
+	defer server.Close()
+
+	cfg := &config{pokedex: newBattlePokedex(), rng: rand.New(rand.NewSource(42))}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+	if _, err := runBattle(cfg, "pikachu"); err == nil {
+		t.Error("Expected error for a battle without an opponent or a wild encounter")
+	}
+
+	pikachu, _ := cfg.pokedex.Get("pikachu")
+	pikachu.Experience = 180
+	cfg.pokedex.Update(pikachu)
+
+	// Без аргументов с диким покемоном сражается первый в команде - pikachu
+	cfg.wild = &encounter.Wild{Pokemon: "squirtle", Level: 5}
+	output, err := runBattle(cfg)
+	if err != nil {
+		t.Fatalf("commandBattle returned error: %v", err)
+	}
+
+	// 63 * 5 / 7 = 45 опыта, 180 + 45 = 225 - это 6 уровень кривой medium
+	expectedStrings := []string{"vs wild squirtle (Lv. 5", "wild squirtle fainted!", "pikachu gained 45 exp. points!", "pikachu grew to level 6!"}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain '%s', got: %s", expected, output)
+		}
+	}
+	if cfg.wild != nil {
+		t.Error("Expected the defeated wild pokemon to be gone")
+	}
+
+	pikachu, _ = cfg.pokedex.Get("pikachu")
+	if pikachu.Level != 6 || pikachu.Experience != 225 || pikachu.GrowthRate != "medium" {
+		t.Errorf("Expected a level 6 pikachu with 225 exp., got %+v", pikachu)
+	}
+	if len(pikachu.LevelStats) == 0 || pikachu.LevelStats[0].BaseStat != 2*35*6/100+6+10 {
+		t.Errorf("Expected stats recomputed for level 6, got %v", pikachu.LevelStats)
+	}
+}
+
+func TestBattleWoundsWildPokemon(t *testing.T) {
+	server := newBattleServer(t)
+	defer server.Close()
+
+	cfg := &config{pokedex: newBattlePokedex(), rng: rand.New(rand.NewSource(42))}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+	// Пикачу 5 уровня проигрывает сквиртлу 12 уровня, но успевает его ранить
+	cfg.wild = &encounter.Wild{Pokemon: "squirtle", Level: 12}
+	output, err := runBattle(cfg)
+	if err != nil {
+		t.Fatalf("commandBattle returned error: %v", err)
+	}
+	if !strings.Contains(output, "wild squirtle wins!") {
+		t.Fatalf("Expected the wild squirtle to win, got: %s", output)
+	}
+	if cfg.wild == nil || cfg.wild.HP <= 0 || cfg.wild.HP >= cfg.wild.MaxHP {
+		t.Fatalf("Expected the wild squirtle to stay wounded, got %+v\n%s", cfg.wild, output)
+	}
+
+	// Следующий бой начинается с оставшимся HP
+	wounded := fmt.Sprintf("wild squirtle (Lv. 12, HP %d)", cfg.wild.HP)
+	if output, _ := runBattle(cfg); !strings.Contains(output, wounded) {
+		t.Errorf("Expected the next battle to start with %q, got: %s", wounded, output)
+	}
+}
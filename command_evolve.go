@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IdrisovMarat/pokemon/internal/evolution"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+// getEvolutionChain загружает цепочку эволюций вида: pokemon-species -> evolution_chain
+func getEvolutionChain(cfg *config, speciesName string) (pokeapi.PokemonSpecies, pokeapi.EvolutionChain, error) {
+	species, err := cfg.client.GetPokemonSpecies(speciesName)
+	if err != nil {
+		return species, pokeapi.EvolutionChain{}, err
+	}
+	chain, err := cfg.client.GetEvolutionChain(species.EvolutionChain.URL)
+	return species, chain, err
+}
+
+// defaultVariety возвращает имя покемона формы вида по умолчанию
+func defaultVariety(species pokeapi.PokemonSpecies) string {
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			return variety.Pokemon.Name
+		}
+	}
+	return species.Name
+}
+
+// commandEvolutions выводит цепочку эволюций вида деревом с условиями
+func commandEvolutions(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide a species name")
+	}
+
+	_, chain, err := getEvolutionChain(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(evolution.Format(chain))
+	return nil
+}
+
+// evolutionState собирает то, что игра знает о покемоне и тренере для условий эволюции.
+// В сумке только покеболы, поэтому HasItem не задается: эволюции предметом недоступны
+func evolutionState(cfg *config, pokemon pokecache.Pokemonmain) evolution.State {
+	state := evolution.State{
+		Level:     pokemonLevel(pokemon),
+		TimeOfDay: evolution.TimeOfDay(time.Now().Hour()),
+	}
+	if cfg.area != nil {
+		state.Location = cfg.area.Location.Name
+	}
+	return state
+}
+
+// commandEvolve превращает пойманного покемона в следующий вид цепочки,
+// если выполнены условия эволюции.
+// --into выбирает вид, если вариантов несколько (eevee)
+func commandEvolve(cfg *config, args ...string) error {
+	opts, rest := parseOptions(args)
+	if len(rest) == 0 {
+		return errors.New("you must provide a pokemon name")
+	}
+
+	pokemon, ok := cfg.pokedex.Get(rest[0])
+	if !ok {
+		return fmt.Errorf("you have not caught %s", rest[0])
+	}
+
+	speciesName := pokemon.Species
+	if speciesName == "" {
+		speciesName = pokemon.Name
+	}
+	species, chain, err := getEvolutionChain(cfg, speciesName)
+	if err != nil {
+		return err
+	}
+
+	options := evolution.Options(chain, species.Name, evolutionState(cfg, pokemon))
+	if into, ok := opts["into"]; ok {
+		filtered := options[:0]
+		for _, option := range options {
+			if option.Into == into {
+				filtered = append(filtered, option)
+			}
+		}
+		options = filtered
+	}
+	if len(options) == 0 {
+		fmt.Printf("%s does not evolve\n", pokemon.DisplayName())
+		return nil
+	}
+
+	var chosen *evolution.Option
+	for i := range options {
+		if options[i].OK() {
+			chosen = &options[i]
+			break
+		}
+	}
+	if chosen == nil {
+		fmt.Printf("%s cannot evolve yet:\n", pokemon.DisplayName())
+		for _, option := range options {
+			fmt.Printf(" - %s (%s): %s\n", option.Into, evolution.Describe(option.Detail), strings.Join(option.Unmet, ", "))
+		}
+		return nil
+	}
+
+	// В цепочке - виды, а покемон вида - его форма по умолчанию (wormadam -> wormadam-plant)
+	targetSpecies, err := cfg.client.GetPokemonSpecies(chosen.Into)
+	if err != nil {
+		return err
+	}
+	target, err := cfg.client.GetPokemon(defaultVariety(targetSpecies))
+	if err != nil {
+		return err
+	}
+
+	evolved := toPokedexEntry(target)
+	evolved.ID = pokemon.ID
+	evolved.Nickname = pokemon.Nickname
+	evolved.Area = pokemon.Area
+	evolved.CreatedAt = pokemon.CreatedAt
+	evolved.Experience = pokemon.Experience
+	// Другие базовые характеристики - пересчитываем для того же уровня
+	setLevel(&evolved, pokemonLevel(pokemon))
+	cfg.pokedex.Update(evolved)
+
+	fmt.Printf("What? %s is evolving!\n", pokemon.DisplayName())
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", pokemon.DisplayName(), evolved.Name)
+	return nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IdrisovMarat/pokemon/internal/inventory"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+const charmanderChain = `{"id":2,"chain":{"species":{"name":"charmander"},"evolution_details":[],"evolves_to":[
+	{"species":{"name":"charmeleon"},"evolution_details":[{"trigger":{"name":"level-up"},"min_level":16}],"evolves_to":[
+		{"species":{"name":"charizard"},"evolution_details":[{"trigger":{"name":"level-up"},"min_level":36}],"evolves_to":[]}]}]}}`
+
+func newEvolutionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/pokemon-species/"):
+			species := pokeapi.PokemonSpecies{Name: name}
+			species.EvolutionChain.URL = "https://pokeapi.co/api/v2/evolution-chain/2/"
+			json.NewEncoder(w).Encode(species)
+		case r.URL.Path == "/evolution-chain/2":
+			w.Write([]byte(charmanderChain))
+		case strings.HasPrefix(r.URL.Path, "/pokemon/"):
+			json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: name, Species: pokeapi.NamedResource{Name: name}, BaseExperience: 142})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestCommandEvolve(t *testing.T) {
+	server := newEvolutionServer(t)
+	defer server.Close()
+
+	cfg := &config{pokedex: pokecache.NewPokedex(), inventory: inventory.New()}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+	charmander := cfg.pokedex.Add(pokecache.Pokemonmain{Name: "charmander", Nickname: "flame", Level: 14})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	errTree := commandEvolutions(cfg, "charmander")
+	errTooLow := commandEvolve(cfg, "flame")
+	charmander.Level = 16
+	cfg.pokedex.Update(charmander)
+	errEvolve := commandEvolve(cfg, "flame")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	if errTree != nil || errTooLow != nil || errEvolve != nil {
+		t.Fatalf("Unexpected errors: %v, %v, %v", errTree, errTooLow, errEvolve)
+	}
+
+	expectedStrings := []string{
+		"└─ charmeleon (level 16)",
+		"   └─ charizard (level 36)",
+		"flame cannot evolve yet:",
+		"charmeleon (level 16): needs level 16 (now 14)",
+		"Your flame evolved into charmeleon!",
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain '%s', got: %s", expected, output)
+		}
+	}
+
+	evolved, ok := cfg.pokedex.Get("flame")
+	if !ok || evolved.Name != "charmeleon" || evolved.ID != charmander.ID || evolved.Level != 16 {
+		t.Errorf("Expected #%d to become a level 16 charmeleon, got %+v", charmander.ID, evolved)
+	}
+	if !cfg.pokedex.Seen("charmeleon") {
+		t.Error("Expected the evolved species to be marked as seen")
+	}
+}
+
+func TestEvolveIntoDefaultVariety(t *testing.T) {
+	const burmyChain = `{"id":213,"chain":{"species":{"name":"burmy"},"evolution_details":[],"evolves_to":[
+		{"species":{"name":"wormadam"},"evolution_details":[{"trigger":{"name":"level-up"},"min_level":20}],"evolves_to":[]}]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon-species/burmy":
+			species := pokeapi.PokemonSpecies{Name: "burmy"}
+			species.EvolutionChain.URL = "https://pokeapi.co/api/v2/evolution-chain/213/"
+			json.NewEncoder(w).Encode(species)
+		case "/pokemon-species/wormadam":
+			json.NewEncoder(w).Encode(pokeapi.PokemonSpecies{Name: "wormadam", Varieties: []pokeapi.PokemonVariety{
+				{Pokemon: pokeapi.NamedResource{Name: "wormadam-sandy"}},
+				{IsDefault: true, Pokemon: pokeapi.NamedResource{Name: "wormadam-plant"}},
+			}})
+		case "/evolution-chain/213":
+			w.Write([]byte(burmyChain))
+		case "/pokemon/wormadam-plant":
+			json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: "wormadam-plant", Species: pokeapi.NamedResource{Name: "wormadam"}})
+		default:
+			// /pokemon/wormadam в PokeAPI нет
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config{pokedex: pokecache.NewPokedex(), inventory: inventory.New()}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+	cfg.pokedex.Add(pokecache.Pokemonmain{Name: "burmy", Level: 20})
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := commandEvolve(cfg, "burmy")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("commandEvolve returned error: %v", err)
+	}
+	if wormadam, ok := cfg.pokedex.Get("#1"); !ok || wormadam.Name != "wormadam-plant" || wormadam.Species != "wormadam" {
+		t.Errorf("Expected burmy to become wormadam-plant, got %+v", wormadam)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/IdrisovMarat/pokemon/internal/encounter"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+// enterArea загружает location-area и делает ее текущей локацией тренера
+func enterArea(cfg *config, name string) (pokeapi.LocationArea, error) {
+	area, err := cfg.client.GetLocationArea(name)
+	if err != nil {
+		return area, err
+	}
+
+	cfg.area = &area
+	// Дикий покемон из встречи walk остался в прежней локации
+	cfg.wild = nil
+	cfg.seen.addArea(area.Name)
+	return area, nil
+}
+
+// livesInArea сообщает, встречается ли покемон в текущей локации в выбранной версии игры
+func livesInArea(cfg *config, pokemon string) bool {
+	if cfg.area == nil {
+		return false
+	}
+	for _, pe := range cfg.area.PokemonEncounters {
+		if pe.Pokemon.Name == pokemon && encounter.Appears(pe, cfg.version) {
+			return true
+		}
+	}
+	return false
+}
+
+// commandGoto переходит в локацию, не печатая ее покемонов.
+// Без аргументов показывает текущую локацию
+func commandGoto(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		if cfg.area == nil {
+			fmt.Println("You are not in any location area yet")
+			return nil
+		}
+		fmt.Printf("You are in %s\n", cfg.area.Name)
+		return nil
+	}
+
+	area, err := enterArea(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("You are now in %s\n", area.Name)
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IdrisovMarat/pokemon/internal/encounter"
+	"github.com/IdrisovMarat/pokemon/internal/inventory"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+func TestCatchRequiresCurrentArea(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/location-area/"):
+			area := pokeapi.LocationArea{Name: name}
+			area.PokemonEncounters = append(area.PokemonEncounters, pokeapi.PokemonEncounter{Pokemon: pokeapi.NamedResource{Name: "tentacool"}})
+			json.NewEncoder(w).Encode(area)
+		case strings.HasPrefix(r.URL.Path, "/pokemon/"):
+			json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: name, BaseExperience: 60})
+		case strings.HasPrefix(r.URL.Path, "/pokemon-species/"):
+			json.NewEncoder(w).Encode(pokeapi.PokemonSpecies{Name: name, CaptureRate: 190})
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config{pokedex: pokecache.NewPokedex(), inventory: inventory.NewStarter()}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+
+	errNowhere := commandCatch(cfg, "tentacool")
+	requestsNowhere := requests
+	cfg.wild = &encounter.Wild{Pokemon: "tentacool", Level: 30}
+	errGoto := commandGoto(cfg, "canalave-city-area")
+	errHere := commandCatch(cfg, "tentacool")
+	errElsewhere := commandCatch(cfg, "mewtwo")
+	cfg.sandbox = true
+	errSandbox := commandCatch(cfg, "mewtwo")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if errNowhere == nil {
+		t.Error("Expected error when catching outside of any area")
+	}
+	if requestsNowhere != 0 {
+		t.Errorf("Expected no requests for a catch outside of any area, got %d", requestsNowhere)
+	}
+	if errGoto != nil || errHere != nil {
+		t.Fatalf("Unexpected errors: %v, %v", errGoto, errHere)
+	}
+	if cfg.area == nil || cfg.area.Name != "canalave-city-area" {
+		t.Errorf("Expected goto to set the current area, got %v", cfg.area)
+	}
+	if cfg.wild != nil {
+		t.Errorf("Expected goto to leave the wild encounter behind, got %+v", cfg.wild)
+	}
+	if errElsewhere == nil || !strings.Contains(errElsewhere.Error(), "canalave-city-area") {
+		t.Errorf("Expected error for a pokemon of another area, got %v", errElsewhere)
+	}
+	if errSandbox != nil {
+		t.Errorf("Expected sandbox mode to allow any catch, got %v", errSandbox)
+	}
+	// Каждая разрешенная попытка тратит покебол, отклоненные - нет
+	if got := cfg.inventory.Count("poke-ball"); got != 18 {
+		t.Errorf("Expected 18 Poke Balls left, got %d", got)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+// validateNickname проверяет прозвище экземпляра pokemon (при поимке у него еще нет ID).
+// Прозвище - ссылка на экземпляр (см. Pokedex.Get), поэтому оно не может быть числом
+// или #3, совпадать с прозвищем другого экземпляра или с именем пойманного вида
+func validateNickname(cfg *config, nickname string, pokemon pokecache.Pokemonmain) error {
+	if nickname == "" {
+		return nil
+	}
+	if _, err := strconv.Atoi(strings.TrimPrefix(nickname, "#")); err == nil {
+		return fmt.Errorf("nickname %s looks like a pokemon number", nickname)
+	}
+	if nickname == pokemon.Name {
+		return fmt.Errorf("nickname %s is the name of its species", nickname)
+	}
+	for _, other := range cfg.pokedex.List() {
+		if other.ID == pokemon.ID {
+			continue
+		}
+		if other.Nickname == nickname {
+			return fmt.Errorf("#%d is already called %s", other.ID, nickname)
+		}
+		if other.Name == nickname {
+			return fmt.Errorf("nickname %s is the name of a caught species", nickname)
+		}
+	}
+	return nil
+}
+
+// commandNickname задает прозвище пойманного покемона, без имени - убирает его
+func commandNickname(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide a pokemon name")
+	}
+
+	pokemon, ok := cfg.pokedex.Get(args[0])
+	if !ok {
+		return fmt.Errorf("you have not caught %s", args[0])
+	}
+
+	pokemon.Nickname = ""
+	if len(args) > 1 {
+		if err := validateNickname(cfg, args[1], pokemon); err != nil {
+			return err
+		}
+		pokemon.Nickname = args[1]
+	}
+	cfg.pokedex.Update(pokemon)
+
+	if pokemon.Nickname == "" {
+		fmt.Printf("#%d %s no longer has a nickname\n", pokemon.ID, pokemon.Name)
+		return nil
+	}
+	fmt.Printf("#%d %s is now called %s\n", pokemon.ID, pokemon.Name, pokemon.Nickname)
+	return nil
+}
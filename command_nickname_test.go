@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IdrisovMarat/pokemon/internal/encounter"
+	"github.com/IdrisovMarat/pokemon/internal/inventory"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+func TestCatchKeepsEveryInstance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/pokemon/"):
+			json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: "pikachu", BaseExperience: 112})
+		case strings.HasPrefix(r.URL.Path, "/pokemon-species/"):
+			json.NewEncoder(w).Encode(pokeapi.PokemonSpecies{Name: "pikachu", CaptureRate: 190})
+		}
+	}))
+	defer server.Close()
+
+	inv := inventory.New()
+	inv.Add("master-ball", 2)
+	cfg := &config{pokedex: pokecache.NewPokedex(), inventory: inv}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+	cfg.area = &pokeapi.LocationArea{
+		Name:              "viridian-forest-area",
+		PokemonEncounters: []pokeapi.PokemonEncounter{{Pokemon: pokeapi.NamedResource{Name: "pikachu"}}},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	cfg.wild = &encounter.Wild{Pokemon: "pikachu", Level: 7}
+	errFirst := commandCatch(cfg, "pikachu", "--ball=master")
+	errSecond := commandCatch(cfg, "pikachu", "--ball=master", "--nickname=sparky")
+	errNumeric := commandNickname(cfg, "#1", "42")
+	errTaken := commandNickname(cfg, "#1", "sparky")
+	errOwnSpecies := commandNickname(cfg, "#1", "pikachu")
+	errCatchTaken := commandCatch(cfg, "pikachu", "--ball=master", "--nickname=sparky")
+	errNickname := commandNickname(cfg, "#1", "volt")
+	errSpecies := commandPokedex(cfg)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	if errFirst != nil || errSecond != nil || errNickname != nil || errSpecies != nil {
+		t.Fatalf("Unexpected errors: %v, %v, %v, %v", errFirst, errSecond, errNickname, errSpecies)
+	}
+	if errNumeric == nil {
+		t.Error("Expected error for a numeric nickname")
+	}
+	// Иначе #1 было бы не найти по прозвищу, а "pikachu" вело бы только к нему
+	if errTaken == nil || errCatchTaken == nil {
+		t.Errorf("Expected errors for a nickname of another instance, got %v, %v", errTaken, errCatchTaken)
+	}
+	if errOwnSpecies == nil {
+		t.Error("Expected error for a nickname equal to a caught species")
+	}
+	if cfg.pokedex.Len() != 2 {
+		t.Fatalf("Expected both pikachus to be kept, got %d", cfg.pokedex.Len())
+	}
+
+	sparky, ok := cfg.pokedex.Get("sparky")
+	if !ok || sparky.ID != 2 || sparky.Area != "viridian-forest-area" {
+		t.Errorf("Expected sparky as #2 caught in viridian-forest-area, got %+v", sparky)
+	}
+	if volt, ok := cfg.pokedex.Get("volt"); !ok || volt.ID != 1 || volt.Level != 7 {
+		t.Errorf("Expected #1 to be renamed volt and keep the encounter level, got %+v", volt)
+	}
+	if sparky.Level != defaultCatchLevel {
+		t.Errorf("Expected #2 caught without an encounter at level %d, got %d", defaultCatchLevel, sparky.Level)
+	}
+
+	expectedStrings := []string{"pikachu was caught! (#1)", "pikachu was caught! (#2)", "#1 pikachu is now called volt", " - pikachu x2"}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain '%s', got: %s", expected, output)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+// formatPokemon выводит экземпляр одной строкой: #3 pikachu "sparky" Lv. 7
+func formatPokemon(pokemon pokecache.Pokemonmain) string {
+	line := fmt.Sprintf("#%d %s", pokemon.ID, pokemon.Name)
+	if pokemon.Nickname != "" {
+		line += fmt.Sprintf(" %q", pokemon.Nickname)
+	}
+	return line + fmt.Sprintf(" Lv. %d", pokemonLevel(pokemon))
+}
+
+// partyMember возвращает покемона команды: по ссылке или, если ссылки нет, первого
+func partyMember(cfg *config, ref string) (pokecache.Pokemonmain, error) {
+	if ref == "" {
+		party := cfg.pokedex.Party()
+		if len(party) == 0 {
+			return pokecache.Pokemonmain{}, errors.New("your party is empty")
+		}
+		return party[0], nil
+	}
+
+	pokemon, ok := cfg.pokedex.Get(ref)
+	if !ok {
+		return pokemon, fmt.Errorf("you have not caught %s", ref)
+	}
+	if pokemon.Box != 0 {
+		return pokemon, fmt.Errorf("%s is in box %d, withdraw it first", pokemon.DisplayName(), pokemon.Box)
+	}
+	return pokemon, nil
+}
+
+// commandParty выводит команду по порядку
+func commandParty(cfg *config, args ...string) error {
+	party := cfg.pokedex.Party()
+	if len(party) == 0 {
+		fmt.Println("Your party is empty")
+		return nil
+	}
+
+	fmt.Println("Your party:")
+	for i, pokemon := range party {
+		fmt.Printf(" %d. %s\n", i+1, formatPokemon(pokemon))
+	}
+	return nil
+}
+
+// commandBox выводит содержимое ящика PC, без номера - список непустых ящиков
+func commandBox(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		boxes := cfg.pokedex.Boxes()
+		if len(boxes) == 0 {
+			fmt.Println("Your PC boxes are empty")
+			return nil
+		}
+		for _, n := range boxes {
+			fmt.Printf("Box %d: %d/%d\n", n, len(cfg.pokedex.Box(n)), pokecache.BoxSize)
+		}
+		return nil
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return fmt.Errorf("invalid box number: %s", args[0])
+	}
+	box := cfg.pokedex.Box(n)
+	if len(box) == 0 {
+		fmt.Printf("Box %d is empty\n", n)
+		return nil
+	}
+
+	fmt.Printf("Box %d:\n", n)
+	for _, pokemon := range box {
+		fmt.Printf(" - %s\n", formatPokemon(pokemon))
+	}
+	return nil
+}
+
+// commandDeposit кладет покемона из команды в ящик PC
+func commandDeposit(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide a pokemon name")
+	}
+
+	box := 0
+	if len(args) > 1 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid box number: %s", args[1])
+		}
+		box = n
+	}
+
+	box, err := cfg.pokedex.Deposit(args[0], box)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s was deposited in box %d\n", args[0], box)
+	return nil
+}
+
+// commandWithdraw забирает покемона из ящика PC в команду
+func commandWithdraw(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide a pokemon name")
+	}
+
+	if err := cfg.pokedex.Withdraw(args[0]); err != nil {
+		return err
+	}
+	fmt.Printf("%s joined your party\n", args[0])
+	return nil
+}
+
+// commandSwap меняет двух покемонов местами в команде или между командой и ящиком
+func commandSwap(cfg *config, args ...string) error {
+	if len(args) < 2 {
+		return errors.New("usage: swap <pokemon> <pokemon>")
+	}
+
+	if err := cfg.pokedex.Swap(args[0], args[1]); err != nil {
+		return err
+	}
+	fmt.Printf("%s and %s swapped places\n", args[0], args[1])
+	return nil
+}
+
+// commandReorder ставит покемона на позицию в команде
+func commandReorder(cfg *config, args ...string) error {
+	if len(args) < 2 {
+		return errors.New("usage: reorder <pokemon> <slot>")
+	}
+
+	slot, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid slot: %s", args[1])
+	}
+	if err := cfg.pokedex.Reorder(args[0], slot); err != nil {
+		return err
+	}
+	return commandParty(cfg)
+}
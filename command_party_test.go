@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+func TestPartyAndBoxCommands(t *testing.T) {
+	cfg := &config{pokedex: pokecache.NewPokedex()}
+	for i := 1; i <= 7; i++ {
+		cfg.pokedex.Add(pokecache.Pokemonmain{Name: fmt.Sprintf("pokemon%d", i), Level: i})
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	errParty := commandParty(cfg)
+	errBoxes := commandBox(cfg)
+	errWithdrawFull := commandWithdraw(cfg, "pokemon7")
+	errSwap := commandSwap(cfg, "pokemon2", "pokemon7")
+	errDeposit := commandDeposit(cfg, "pokemon3", "2")
+	errBox := commandBox(cfg, "2")
+	errReorder := commandReorder(cfg, "pokemon6", "1")
+	errBattleBoxed := commandBattle(cfg, "pokemon2", "pokemon1")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	for i, err := range []error{errParty, errBoxes, errSwap, errDeposit, errBox, errReorder} {
+		if err != nil {
+			t.Errorf("command %d returned error: %v", i, err)
+		}
+	}
+	if errWithdrawFull == nil {
+		t.Error("Expected withdraw into a full party to fail")
+	}
+	if errBattleBoxed == nil || !strings.Contains(errBattleBoxed.Error(), "is in box 1") {
+		t.Errorf("Expected battles to use party pokemons only, got %v", errBattleBoxed)
+	}
+
+	expectedStrings := []string{
+		" 1. #1 pokemon1 Lv. 1",
+		"Box 1: 1/30",
+		"pokemon3 was deposited in box 2",
+		"Box 2:\n - #3 pokemon3 Lv. 3",
+		" 1. #6 pokemon6 Lv. 6\n 2. #1 pokemon1 Lv. 1\n 3. #7 pokemon7 Lv. 7",
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain '%s', got: %s", expected, output)
+		}
+	}
+}
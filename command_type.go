@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/typechart"
+)
+
+// getTypeChart загружает таблицу типов при первом обращении
+func getTypeChart(cfg *config) (*typechart.Chart, error) {
+	if cfg.typeChart != nil {
+		return cfg.typeChart, nil
+	}
+	chart, err := typechart.Load(&cfg.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the type chart: %w", err)
+	}
+	cfg.typeChart = chart
+	return chart, nil
+}
+
+func typeNames(resources []pokeapi.NamedResource) string {
+	if len(resources) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, r.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// commandType показывает сильные и слабые стороны типа,
+// а для двух типов - множители урона по покемону с такой парой типов
+func commandType(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide a type name")
+	}
+
+	chart, err := getTypeChart(cfg)
+	if err != nil {
+		return err
+	}
+	for _, name := range args {
+		if _, ok := chart.Type(name); !ok {
+			return fmt.Errorf("unknown type: %s", name)
+		}
+	}
+
+	if len(args) == 1 {
+		t, _ := chart.Type(args[0])
+		relations := t.DamageRelations
+		fmt.Println(t.Name)
+		fmt.Println("Attacking:")
+		fmt.Printf("  super effective (x2) against: %s\n", typeNames(relations.DoubleDamageTo))
+		fmt.Printf("  not very effective (x0.5) against: %s\n", typeNames(relations.HalfDamageTo))
+		fmt.Printf("  no effect on: %s\n", typeNames(relations.NoDamageTo))
+		fmt.Println("Defending:")
+		fmt.Printf("  weak to (x2): %s\n", typeNames(relations.DoubleDamageFrom))
+		fmt.Printf("  resists (x0.5): %s\n", typeNames(relations.HalfDamageFrom))
+		fmt.Printf("  immune to: %s\n", typeNames(relations.NoDamageFrom))
+		return nil
+	}
+
+	defense := chart.Defense(args...)
+	multipliers := make([]float64, 0, len(defense))
+	for m := range defense {
+		multipliers = append(multipliers, m)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(multipliers)))
+
+	fmt.Printf("Damage taken by a %s pokemon:\n", strings.Join(args, "/"))
+	for _, m := range multipliers {
+		label := fmt.Sprintf("x%g", m)
+		if m == 0 {
+			label = "immune"
+		}
+		fmt.Printf("  %s: %s\n", label, strings.Join(defense[m], ", "))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+func TestCommandType(t *testing.T) {
+	server := newBattleServer(t)
+	defer server.Close()
+
+	cfg := &config{}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	errSingle := commandType(cfg, "water")
+	errDual := commandType(cfg, "water", "electric")
+	errUnknown := commandType(cfg, "cosmic")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	if errSingle != nil || errDual != nil {
+		t.Fatalf("commandType returned errors: %v, %v", errSingle, errDual)
+	}
+	if errUnknown == nil {
+		t.Error("Expected error for an unknown type")
+	}
+
+	expectedStrings := []string{"weak to (x2): electric", "resists (x0.5): -", "Damage taken by a water/electric pokemon:", "x0.5: water"}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain '%s', got: %s", expected, output)
+		}
+	}
+}
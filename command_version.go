@@ -0,0 +1,42 @@
+package main
+
+import "fmt"
+
+// versionAll снимает фильтр по версии игры
+const versionAll = "all"
+
+// commandVersion задает версию игры, по которой фильтруются explore, walk, catch и map.
+// Без аргументов показывает текущую версию
+func commandVersion(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		if cfg.version == "" {
+			fmt.Println("Showing encounters of all game versions")
+			return nil
+		}
+		fmt.Printf("Showing encounters of %s\n", cfg.version)
+		return nil
+	}
+
+	if args[0] == versionAll {
+		setVersion(cfg, "")
+		fmt.Println("Showing encounters of all game versions")
+		return nil
+	}
+
+	version, err := cfg.client.GetVersion(args[0])
+	if err != nil {
+		return fmt.Errorf("unknown game version %s: %w", args[0], err)
+	}
+
+	setVersion(cfg, version.Name)
+	fmt.Printf("Showing encounters of %s\n", cfg.version)
+	return nil
+}
+
+// setVersion меняет версию игры. Встреча walk была в другой версии, поэтому забывается
+func setVersion(cfg *config, version string) {
+	if version != cfg.version {
+		cfg.wild = nil
+	}
+	cfg.version = version
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IdrisovMarat/pokemon/internal/encounter"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+func newVersionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	encounterIn := func(pokemon string, versions ...string) pokeapi.PokemonEncounter {
+		pe := pokeapi.PokemonEncounter{Pokemon: pokeapi.NamedResource{Name: pokemon}}
+		for _, v := range versions {
+			pe.VersionDetails = append(pe.VersionDetails, pokeapi.VersionEncounterDetail{
+				Version:          pokeapi.NamedResource{Name: v},
+				EncounterDetails: []pokeapi.Encounter{{Method: pokeapi.NamedResource{Name: "walk"}, Chance: 50, MinLevel: 2, MaxLevel: 4}},
+			})
+		}
+		return pe
+	}
+	areas := map[string]pokeapi.LocationArea{
+		"sinnoh-route-201-area": {Name: "sinnoh-route-201-area", PokemonEncounters: []pokeapi.PokemonEncounter{
+			encounterIn("starly", "diamond", "platinum"),
+			encounterIn("kricketot", "diamond"),
+		}},
+		"kanto-route-1-area": {Name: "kanto-route-1-area", PokemonEncounters: []pokeapi.PokemonEncounter{
+			encounterIn("pidgey", "red"),
+		}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path[strings.LastIndex(strings.TrimSuffix(r.URL.Path, "/"), "/"):], "/")
+		switch {
+		case r.URL.Path == "/location-area/":
+			json.NewEncoder(w).Encode(pokeapi.LocationAreaList{Results: []pokeapi.NamedResource{{Name: "sinnoh-route-201-area"}, {Name: "kanto-route-1-area"}}})
+		case strings.HasPrefix(r.URL.Path, "/location-area/"):
+			json.NewEncoder(w).Encode(areas[name])
+		case r.URL.Path == "/version/platinum":
+			json.NewEncoder(w).Encode(pokeapi.Version{Name: "platinum"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestCommandVersion(t *testing.T) {
+	server := newVersionServer(t)
+	defer server.Close()
+
+	cfg := &config{limit: 20, pokedex: pokecache.NewPokedex()}
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Stop()
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, cache)
+
+	capture := func(run func()) string {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+		run()
+		w.Close()
+		os.Stdout = oldStdout
+
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		return buf.String()
+	}
+
+	all := capture(func() { commandExplore(cfg, "sinnoh-route-201-area") })
+	if !strings.Contains(all, "starly (diamond, platinum)") || !strings.Contains(all, "kricketot (diamond)") {
+		t.Errorf("Expected versions of every pokemon without a filter, got: %s", all)
+	}
+
+	if err := commandVersion(cfg, "emerald-ish"); err == nil {
+		t.Error("Expected error for an unknown version")
+	}
+	cfg.wild = &encounter.Wild{Pokemon: "kricketot", Level: 3}
+	if err := commandVersion(cfg, "platinum"); err != nil {
+		t.Fatalf("commandVersion returned error: %v", err)
+	}
+	if cfg.wild != nil {
+		t.Errorf("Expected a version change to drop the wild encounter, got %+v", cfg.wild)
+	}
+
+	filtered := capture(func() { commandExplore(cfg, "sinnoh-route-201-area") })
+	if !strings.Contains(filtered, "starly") || strings.Contains(filtered, "kricketot") || strings.Contains(filtered, "(") {
+		t.Errorf("Expected only platinum pokemons, got: %s", filtered)
+	}
+	if livesInArea(cfg, "kricketot") {
+		t.Error("Expected kricketot not to be catchable in platinum")
+	}
+
+	// Сообщение о кэше только у самого списка, локации для фильтра загружаются молча
+	areas := capture(func() { commandMap(cfg) })
+	if areas != "...DATA CACHED...\nsinnoh-route-201-area\n" {
+		t.Errorf("Expected only areas with platinum encounters, got: %q", areas)
+	}
+
+	cfg.wild = &encounter.Wild{Pokemon: "starly", Level: 3}
+	capture(func() { commandVersion(cfg, "all") })
+	if cfg.version != "" {
+		t.Errorf("Expected 'version all' to clear the filter, got %q", cfg.version)
+	}
+	if cfg.wild != nil {
+		t.Errorf("Expected 'version all' to drop the wild encounter, got %+v", cfg.wild)
+	}
+}
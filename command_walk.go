@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/IdrisovMarat/pokemon/internal/encounter"
+)
+
+// commandWalk ищет дикого покемона в текущей локации.
+// Покемон выбирается с весом по шансу встречи для способа --method
+// в выбранной версии игры, а без нее - в случайной из версий локации.
+// Встречает его первый покемон команды
+func commandWalk(cfg *config, args ...string) error {
+	if cfg.area == nil {
+		return errors.New("you are not in any location area, use explore or goto first")
+	}
+
+	opts, _ := parseOptions(args)
+	method := encounter.DefaultMethod
+	if m, ok := opts["method"]; ok {
+		method = m
+	}
+
+	wild, ok := encounter.Find(*cfg.area, method, cfg.version, cfg.random())
+	if !ok {
+		methods := encounter.Methods(*cfg.area, cfg.version)
+		if len(methods) == 0 {
+			return fmt.Errorf("no wild pokemons in %s", cfg.area.Name)
+		}
+		return fmt.Errorf("no %s encounters in %s, try: %s", method, cfg.area.Name, strings.Join(methods, ", "))
+	}
+
+	cfg.wild = &wild
+	cfg.seen.addPokemon(wild.Pokemon)
+	cfg.pokedex.MarkSeen(wild.Pokemon)
+	fmt.Printf("A wild %s (Lv. %d) appeared!\n", wild.Pokemon, wild.Level)
+
+	// С диким покемоном встречается первый в команде
+	if lead, err := partyMember(cfg, ""); err == nil {
+		fmt.Printf("Your %s (Lv. %d) is ready, battle it or catch it!\n", lead.DisplayName(), pokemonLevel(lead))
+	}
+	return nil
+}
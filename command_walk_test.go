@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+func TestCommandWalk(t *testing.T) {
+	cfg := &config{pokedex: pokecache.NewPokedex(), rng: rand.New(rand.NewSource(1))}
+
+	if err := commandWalk(cfg); err == nil {
+		t.Error("Expected error when walking outside of any area")
+	}
+
+	cfg.area = &pokeapi.LocationArea{
+		Name: "canalave-city-area",
+		PokemonEncounters: []pokeapi.PokemonEncounter{{
+			Pokemon: pokeapi.NamedResource{Name: "tentacool"},
+			VersionDetails: []pokeapi.VersionEncounterDetail{{
+				EncounterDetails: []pokeapi.Encounter{{Method: pokeapi.NamedResource{Name: "surf"}, Chance: 60, MinLevel: 20, MaxLevel: 30}},
+			}},
+		}},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	errWalk := commandWalk(cfg)
+	errSurf := commandWalk(cfg, "--method=surf")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	if errWalk == nil || !strings.Contains(errWalk.Error(), "try: surf") {
+		t.Errorf("Expected error listing the available methods, got %v", errWalk)
+	}
+	if errSurf != nil {
+		t.Fatalf("commandWalk returned error: %v", errSurf)
+	}
+	if !strings.Contains(output, "A wild tentacool (Lv. ") {
+		t.Errorf("Expected a tentacool encounter, got: %s", output)
+	}
+	if !cfg.pokedex.Seen("tentacool") {
+		t.Error("Expected the wild pokemon to be marked as seen")
+	}
+	if cfg.wild == nil || cfg.wild.Level < 20 || cfg.wild.Level > 30 {
+		t.Errorf("Expected the encounter to be kept with a level in 20-30, got %v", cfg.wild)
+	}
+}
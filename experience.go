@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/IdrisovMarat/pokemon/internal/battle"
+	"github.com/IdrisovMarat/pokemon/internal/growth"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+// levelStats рассчитывает характеристики для уровня по базовым
+func levelStats(base []pokecache.Stat, level int) []pokecache.Stat {
+	stats := battle.StatsAt(baseStats(base), level)
+	values := map[string]int{
+		"hp":              stats.HP,
+		"attack":          stats.Attack,
+		"defense":         stats.Defense,
+		"special-attack":  stats.SpecialAttack,
+		"special-defense": stats.SpecialDefense,
+		"speed":           stats.Speed,
+	}
+
+	result := make([]pokecache.Stat, 0, len(base))
+	for _, s := range base {
+		if value, ok := values[s.Name]; ok {
+			result = append(result, pokecache.Stat{Name: s.Name, BaseStat: value})
+		}
+	}
+	return result
+}
+
+// setLevel задает уровень покемона и пересчитывает его характеристики
+func setLevel(pokemon *pokecache.Pokemonmain, level int) {
+	pokemon.Level = level
+	pokemon.LevelStats = levelStats(pokemon.Stats, level)
+}
+
+// getGrowthRate возвращает кривую опыта покемона. Имя кривой берется из вида
+// и запоминается в записи, сами кривые кэшируются на сессию
+func getGrowthRate(cfg *config, pokemon *pokecache.Pokemonmain) (pokeapi.GrowthRate, error) {
+	if pokemon.GrowthRate == "" {
+		speciesName := pokemon.Species
+		if speciesName == "" {
+			speciesName = pokemon.Name
+		}
+		species, err := cfg.client.GetPokemonSpecies(speciesName)
+		if err != nil {
+			return pokeapi.GrowthRate{}, err
+		}
+		pokemon.GrowthRate = species.GrowthRate.Name
+	}
+
+	if rate, ok := cfg.growthRates[pokemon.GrowthRate]; ok {
+		return rate, nil
+	}
+	rate, err := cfg.client.GetGrowthRate(pokemon.GrowthRate)
+	if err != nil {
+		return rate, err
+	}
+	if cfg.growthRates == nil {
+		cfg.growthRates = make(map[string]pokeapi.GrowthRate)
+	}
+	cfg.growthRates[pokemon.GrowthRate] = rate
+	return rate, nil
+}
+
+// gainExperience начисляет опыт пойманному покемону и повышает уровень по кривой вида
+func gainExperience(cfg *config, pokemon pokecache.Pokemonmain, exp int) error {
+	rate, err := getGrowthRate(cfg, &pokemon)
+	if err != nil {
+		return err
+	}
+
+	level := pokemonLevel(pokemon)
+	// Опыт не может быть меньше начала текущего уровня (пойманные, старые сохранения)
+	pokemon.Experience = max(pokemon.Experience, growth.Experience(rate, level)) + exp
+	fmt.Printf("%s gained %d exp. points!\n", pokemon.DisplayName(), exp)
+
+	if newLevel := growth.Level(rate, pokemon.Experience); newLevel > level {
+		setLevel(&pokemon, newLevel)
+		fmt.Printf("%s grew to level %d!\n", pokemon.DisplayName(), newLevel)
+	}
+
+	cfg.pokedex.Update(pokemon)
+	return nil
+}
@@ -0,0 +1,205 @@
+// Package battle - пошаговый бой двух покемонов.
+// Вся случайность берется из переданного *rand.Rand, поэтому
+// при одинаковом seed бой проходит одинаково
+package battle
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// MaxTurns - после стольких ходов бой заканчивается ничьей
+const MaxTurns = 100
+
+// Stats - базовые характеристики вида (base_stat из PokeAPI)
+type Stats struct {
+	HP             int
+	Attack         int
+	Defense        int
+	SpecialAttack  int
+	SpecialDefense int
+	Speed          int
+}
+
+// Move - атакующий прием
+type Move struct {
+	Name string
+	Type string
+	// DamageClass - "physical" или "special"
+	DamageClass string
+	Power       int
+	// Accuracy в процентах, 0 - прием не промахивается
+	Accuracy int
+}
+
+// Struggle используется, если у покемона нет атакующих приемов
+var Struggle = Move{Name: "struggle", DamageClass: "physical", Power: 50}
+
+// Combatant - участник боя
+type Combatant struct {
+	Name  string
+	Level int
+	Types []string
+	Base  Stats
+	Moves []Move
+	// HP - текущее HP перед боем, 0 - полное
+	HP int
+}
+
+// Effectiveness возвращает множитель урона приема типа attackType
+// по защитнику с типами defenderTypes (1 - обычный урон)
+type Effectiveness func(attackType string, defenderTypes []string) float64
+
+// Neutral считает все приемы обычными по эффективности
+func Neutral(string, []string) float64 {
+	return 1
+}
+
+// Result - итог боя
+type Result struct {
+	// Winner - имя победителя, пустое при ничьей
+	Winner string
+	Turns  int
+	Log    []string
+	// HP и MaxHP - оставшееся и полное HP участников a и b после боя
+	HP    [2]int
+	MaxHP [2]int
+}
+
+// fighter - участник с рассчитанными для уровня характеристиками
+type fighter struct {
+	Combatant
+	stats Stats
+	hp    int
+}
+
+func newFighter(c Combatant) *fighter {
+	if c.Level <= 0 {
+		c.Level = 50
+	}
+	if len(c.Moves) == 0 {
+		c.Moves = []Move{Struggle}
+	}
+	stats := StatsAt(c.Base, c.Level)
+	hp := stats.HP
+	if c.HP > 0 {
+		hp = min(c.HP, stats.HP)
+	}
+	return &fighter{Combatant: c, stats: stats, hp: hp}
+}
+
+// StatsAt рассчитывает характеристики для уровня level по базовым
+// (формула игр без учета IV и EV)
+func StatsAt(base Stats, level int) Stats {
+	other := func(b int) int {
+		return 2*b*level/100 + 5
+	}
+	return Stats{
+		HP:             2*base.HP*level/100 + level + 10,
+		Attack:         other(base.Attack),
+		Defense:        other(base.Defense),
+		SpecialAttack:  other(base.SpecialAttack),
+		SpecialDefense: other(base.SpecialDefense),
+		Speed:          other(base.Speed),
+	}
+}
+
+// Fight проводит бой a против b. eff может быть nil - тогда используется Neutral
+func Fight(a, b Combatant, rng *rand.Rand, eff Effectiveness) Result {
+	if eff == nil {
+		eff = Neutral
+	}
+	first, second := newFighter(a), newFighter(b)
+	result := Result{MaxHP: [2]int{first.stats.HP, second.stats.HP}}
+	logf := func(format string, args ...any) {
+		result.Log = append(result.Log, fmt.Sprintf(format, args...))
+	}
+
+	logf("%s (Lv. %d, HP %d) vs %s (Lv. %d, HP %d)!",
+		first.Name, first.Level, first.hp, second.Name, second.Level, second.hp)
+
+	for result.Turns < MaxTurns {
+		result.Turns++
+		logf("--- Turn %d ---", result.Turns)
+
+		// Первым ходит более быстрый, при равной скорости - случайный
+		attacker, defender := first, second
+		if second.stats.Speed > first.stats.Speed ||
+			second.stats.Speed == first.stats.Speed && rng.Intn(2) == 1 {
+			attacker, defender = second, first
+		}
+
+		for i := 0; i < 2; i++ {
+			attack(attacker, defender, rng, eff, logf)
+			if defender.hp == 0 {
+				logf("%s fainted!", defender.Name)
+				logf("%s wins!", attacker.Name)
+				result.Winner = attacker.Name
+				result.HP = [2]int{first.hp, second.hp}
+				return result
+			}
+			attacker, defender = defender, attacker
+		}
+	}
+
+	logf("The battle ended in a draw after %d turns", MaxTurns)
+	result.HP = [2]int{first.hp, second.hp}
+	return result
+}
+
+// attack - один ход атакующего
+func attack(attacker, defender *fighter, rng *rand.Rand, eff Effectiveness, logf func(string, ...any)) {
+	move := attacker.Moves[rng.Intn(len(attacker.Moves))]
+	logf("%s used %s!", attacker.Name, move.Name)
+
+	if move.Accuracy > 0 && rng.Intn(100) >= move.Accuracy {
+		logf("%s's attack missed!", attacker.Name)
+		return
+	}
+
+	multiplier := 1.0
+	if move.Type != "" {
+		multiplier = eff(move.Type, defender.Types)
+	}
+	if multiplier == 0 {
+		logf("It doesn't affect %s...", defender.Name)
+		return
+	}
+
+	atk, def := attacker.stats.Attack, defender.stats.Defense
+	if move.DamageClass == "special" {
+		atk, def = attacker.stats.SpecialAttack, defender.stats.SpecialDefense
+	}
+
+	damage := float64((2*attacker.Level/5+2)*move.Power*atk/def)/50 + 2
+
+	// STAB - бонус за прием своего типа
+	for _, t := range attacker.Types {
+		if t == move.Type {
+			damage *= 1.5
+			break
+		}
+	}
+	damage *= multiplier
+
+	critical := rng.Intn(24) == 0
+	if critical {
+		damage *= 1.5
+	}
+	// Разброс урона 85-100%
+	damage *= float64(85+rng.Intn(16)) / 100
+
+	dealt := max(int(damage), 1)
+	defender.hp = max(defender.hp-dealt, 0)
+
+	if critical {
+		logf("A critical hit!")
+	}
+	switch {
+	case multiplier > 1:
+		logf("It's super effective!")
+	case multiplier < 1:
+		logf("It's not very effective...")
+	}
+	logf("%s took %d damage (HP %d/%d)", defender.Name, dealt, defender.hp, defender.stats.HP)
+}
@@ -0,0 +1,116 @@
+package battle
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var pikachu = Combatant{
+	Name:  "pikachu",
+	Level: 50,
+	Types: []string{"electric"},
+	Base:  Stats{HP: 35, Attack: 55, Defense: 40, SpecialAttack: 50, SpecialDefense: 50, Speed: 90},
+	Moves: []Move{
+		{Name: "thunderbolt", Type: "electric", DamageClass: "special", Power: 90, Accuracy: 100},
+		{Name: "quick-attack", Type: "normal", DamageClass: "physical", Power: 40, Accuracy: 100},
+	},
+}
+
+var squirtle = Combatant{
+	Name:  "squirtle",
+	Level: 50,
+	Types: []string{"water"},
+	Base:  Stats{HP: 44, Attack: 48, Defense: 65, SpecialAttack: 50, SpecialDefense: 64, Speed: 43},
+	Moves: []Move{
+		{Name: "water-gun", Type: "water", DamageClass: "special", Power: 40, Accuracy: 100},
+		{Name: "tackle", Type: "normal", DamageClass: "physical", Power: 40, Accuracy: 100},
+	},
+}
+
+func TestFightIsDeterministicForSeed(t *testing.T) {
+	first := Fight(pikachu, squirtle, rand.New(rand.NewSource(42)), nil)
+	second := Fight(pikachu, squirtle, rand.New(rand.NewSource(42)), nil)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected identical battles for the same seed")
+	}
+	if first.Winner == "" {
+		t.Errorf("expected a winner, got a draw")
+	}
+	if !strings.HasSuffix(first.Log[len(first.Log)-1], first.Winner+" wins!") {
+		t.Errorf("expected log to end with the winner, got %q", first.Log[len(first.Log)-1])
+	}
+}
+
+func TestFasterPokemonMovesFirst(t *testing.T) {
+	result := Fight(squirtle, pikachu, rand.New(rand.NewSource(1)), nil)
+	if !strings.HasPrefix(result.Log[2], "pikachu used") {
+		t.Errorf("expected pikachu to move first, got %q", result.Log[2])
+	}
+}
+
+func TestEffectiveness(t *testing.T) {
+	electricOnly := pikachu
+	electricOnly.Moves = electricOnly.Moves[:1]
+
+	immune := func(attackType string, defenderTypes []string) float64 {
+		return 0
+	}
+	result := Fight(electricOnly, squirtle, rand.New(rand.NewSource(7)), immune)
+	if !strings.Contains(strings.Join(result.Log, "\n"), "It doesn't affect squirtle") {
+		t.Errorf("expected immunity message in log")
+	}
+
+	superEffective := func(attackType string, defenderTypes []string) float64 {
+		if attackType == "electric" && defenderTypes[0] == "water" {
+			return 2
+		}
+		return 1
+	}
+	result = Fight(electricOnly, squirtle, rand.New(rand.NewSource(7)), superEffective)
+	if !strings.Contains(strings.Join(result.Log, "\n"), "It's super effective!") {
+		t.Errorf("expected super effective message in log")
+	}
+	if result.Winner != "pikachu" {
+		t.Errorf("expected pikachu to win with super effective moves, got %q", result.Winner)
+	}
+}
+
+func TestStatsAt(t *testing.T) {
+	stats := StatsAt(pikachu.Base, 50)
+	expected := Stats{HP: 95, Attack: 60, Defense: 45, SpecialAttack: 55, SpecialDefense: 55, Speed: 95}
+	if stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestStruggleWithoutMoves(t *testing.T) {
+	noMoves := squirtle
+	noMoves.Moves = nil
+	result := Fight(noMoves, pikachu, rand.New(rand.NewSource(3)), nil)
+	if !strings.Contains(strings.Join(result.Log, "\n"), "squirtle used struggle!") {
+		t.Errorf("expected squirtle to use struggle")
+	}
+}
+
+func TestFightReportsRemainingHP(t *testing.T) {
+	wounded := squirtle
+	wounded.HP = 10
+	result := Fight(pikachu, wounded, rand.New(rand.NewSource(42)), nil)
+
+	if !strings.Contains(result.Log[0], "squirtle (Lv. 50, HP 10)") {
+		t.Errorf("expected squirtle to start wounded, got %q", result.Log[0])
+	}
+	if result.MaxHP != [2]int{StatsAt(pikachu.Base, 50).HP, StatsAt(squirtle.Base, 50).HP} {
+		t.Errorf("unexpected max HP %v", result.MaxHP)
+	}
+	loser := 1
+	if result.Winner == "squirtle" {
+		loser = 0
+	}
+	if result.HP[loser] != 0 || result.HP[1-loser] <= 0 || result.HP[1-loser] > result.MaxHP[1-loser] {
+		t.Errorf("unexpected remaining HP %v for winner %s", result.HP, result.Winner)
+	}
+}
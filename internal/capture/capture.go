@@ -0,0 +1,94 @@
+// Package capture - расчет поимки покемона по формуле игр (поколения III-IV):
+// capture_rate вида, модификатор мяча, доля оставшегося HP и статус цели,
+// затем четыре проверки встряски мяча
+package capture
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Status - основной статус цели
+type Status string
+
+const (
+	StatusNone      Status = ""
+	StatusSleep     Status = "sleep"
+	StatusFreeze    Status = "freeze"
+	StatusParalysis Status = "paralysis"
+	StatusBurn      Status = "burn"
+	StatusPoison    Status = "poison"
+)
+
+// StatusBonus возвращает множитель поимки для статуса
+func StatusBonus(s Status) float64 {
+	switch s {
+	case StatusSleep, StatusFreeze:
+		return 2
+	case StatusParalysis, StatusBurn, StatusPoison:
+		return 1.5
+	default:
+		return 1
+	}
+}
+
+// Attempt - параметры одного броска
+type Attempt struct {
+	// CaptureRate - capture_rate вида из PokeAPI (3 у легендарных, 255 у самых простых)
+	CaptureRate int
+	// BallModifier - 1 у Poké Ball, 1.5 у Great Ball, 2 у Ultra Ball
+	BallModifier float64
+	MaxHP        int
+	CurrentHP    int
+	Status       Status
+}
+
+// Result - итог броска. Shakes - сколько проверок встряски прошел мяч (0-4)
+type Result struct {
+	Caught bool
+	Shakes int
+}
+
+// shakeChecks - сколько проверок встряски нужно пройти для поимки
+const shakeChecks = 4
+
+// Rate возвращает модифицированный шанс поимки a (при a >= 255 поимка гарантирована)
+func Rate(a Attempt) float64 {
+	maxHP := float64(max(a.MaxHP, 1))
+	currentHP := math.Min(math.Max(float64(a.CurrentHP), 1), maxHP)
+	ball := a.BallModifier
+	if ball <= 0 {
+		ball = 1
+	}
+	return (3*maxHP - 2*currentHP) * float64(a.CaptureRate) * ball / (3 * maxHP) * StatusBonus(a.Status)
+}
+
+// ShakeProbability возвращает вероятность пройти одну проверку встряски
+func ShakeProbability(a Attempt) float64 {
+	rate := Rate(a)
+	if rate >= 255 {
+		return 1
+	}
+	if rate <= 0 {
+		return 0
+	}
+	// Поколения III-IV: b = 1048560 / sqrt(sqrt(16711680/a)),
+	// проверка проходит, если случайное число от 0 до 65535 < b
+	b := 1048560 / math.Sqrt(math.Sqrt(16711680/rate))
+	return b / 65536
+}
+
+// Throw бросает мяч: поимка удалась, если пройдены все четыре проверки встряски
+func Throw(a Attempt, rng *rand.Rand) Result {
+	if Rate(a) >= 255 {
+		return Result{Caught: true, Shakes: shakeChecks}
+	}
+
+	b := int(ShakeProbability(a) * 65536)
+	for shakes := 0; shakes < shakeChecks; shakes++ {
+		if rng.Intn(65536) >= b {
+			return Result{Caught: false, Shakes: shakes}
+		}
+	}
+	return Result{Caught: true, Shakes: shakeChecks}
+}
@@ -0,0 +1,92 @@
+package capture
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRate(t *testing.T) {
+	cases := []struct {
+		name     string
+		attempt  Attempt
+		expected float64
+	}{
+		{name: "full hp", attempt: Attempt{CaptureRate: 45, BallModifier: 1, MaxHP: 100, CurrentHP: 100}, expected: 15},
+		{name: "one hp", attempt: Attempt{CaptureRate: 45, BallModifier: 1, MaxHP: 100, CurrentHP: 1}, expected: 44.7},
+		{name: "ultra ball asleep", attempt: Attempt{CaptureRate: 45, BallModifier: 2, MaxHP: 100, CurrentHP: 100, Status: StatusSleep}, expected: 60},
+		{name: "paralysis", attempt: Attempt{CaptureRate: 255, BallModifier: 1, MaxHP: 30, CurrentHP: 30, Status: StatusParalysis}, expected: 127.5},
+	}
+
+	for _, c := range cases {
+		if got := Rate(c.attempt); math.Abs(got-c.expected) > 0.001 {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestShakeProbabilityGen3to4(t *testing.T) {
+	// b = 1048560 / sqrt(sqrt(16711680/a)), вероятность - b/65536
+	cases := []struct {
+		name     string
+		attempt  Attempt
+		expected float64
+	}{
+		{name: "a = 15", attempt: Attempt{CaptureRate: 45, BallModifier: 1, MaxHP: 100, CurrentHP: 100}, expected: 32274.615 / 65536},
+		{name: "a = 1", attempt: Attempt{CaptureRate: 3, BallModifier: 1, MaxHP: 1, CurrentHP: 1}, expected: 16399.789 / 65536},
+		{name: "a = 255", attempt: Attempt{CaptureRate: 255, BallModifier: 1, MaxHP: 100, CurrentHP: 1, Status: StatusParalysis}, expected: 1},
+	}
+
+	for _, c := range cases {
+		if got := ShakeProbability(c.attempt); math.Abs(got-c.expected) > 0.0001 {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestThrowGuaranteedCatch(t *testing.T) {
+	// Master Ball дает гарантированную поимку
+	attempt := Attempt{CaptureRate: 3, BallModifier: 255, MaxHP: 100, CurrentHP: 100}
+	result := Throw(attempt, rand.New(rand.NewSource(1)))
+	if !result.Caught || result.Shakes != 4 {
+		t.Errorf("expected guaranteed catch, got %+v", result)
+	}
+}
+
+func TestThrowMatchesShakeProbability(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	legendary := Attempt{CaptureRate: 3, BallModifier: 1, MaxHP: 100, CurrentHP: 100}
+	common := Attempt{CaptureRate: 255, BallModifier: 1, MaxHP: 100, CurrentHP: 100}
+
+	const throws = 10000
+	caught := map[string]int{}
+	for i := 0; i < throws; i++ {
+		if Throw(legendary, rng).Caught {
+			caught["legendary"]++
+		}
+		if Throw(common, rng).Caught {
+			caught["common"]++
+		}
+	}
+
+	for name, attempt := range map[string]Attempt{"legendary": legendary, "common": common} {
+		expected := math.Pow(ShakeProbability(attempt), 4)
+		got := float64(caught[name]) / throws
+		if math.Abs(got-expected) > 0.02 {
+			t.Errorf("%s: expected catch rate about %.3f, got %.3f", name, expected, got)
+		}
+	}
+	if caught["legendary"] >= caught["common"] {
+		t.Errorf("expected legendaries to be harder to catch: %v", caught)
+	}
+}
+
+func TestThrowIsDeterministicForSeed(t *testing.T) {
+	attempt := Attempt{CaptureRate: 45, BallModifier: 1, MaxHP: 100, CurrentHP: 50}
+	first, second := rand.New(rand.NewSource(9)), rand.New(rand.NewSource(9))
+	for i := 0; i < 20; i++ {
+		if Throw(attempt, first) != Throw(attempt, second) {
+			t.Fatalf("expected identical throws for the same seed")
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Package encounter - встречи с дикими покемонами в локации:
+// выбор покемона с весом по шансу встречи для способа (walk, surf, old-rod...)
+// и случайный уровень из диапазона min_level - max_level
+package encounter
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+// DefaultMethod - способ встречи по умолчанию (ходьба по траве)
+const DefaultMethod = "walk"
+
+// Slot - одна запись таблицы встреч: покемон, его шанс и уровни
+type Slot struct {
+	Pokemon  string
+	Chance   int
+	MinLevel int
+	MaxLevel int
+}
+
+// Wild - встреченный дикий покемон
+type Wild struct {
+	Pokemon string
+	Level   int
+	// HP и MaxHP - HP после боя с ним; 0 - покемон еще не ранен
+	HP    int
+	MaxHP int
+}
+
+// Appears сообщает, встречается ли покемон в версии игры version.
+// Пустая version означает любую версию
+func Appears(pe pokeapi.PokemonEncounter, version string) bool {
+	if version == "" {
+		return true
+	}
+	for _, vd := range pe.VersionDetails {
+		if vd.Version.Name == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Versions возвращает версии игры, в которых встречается покемон
+func Versions(pe pokeapi.PokemonEncounter) []string {
+	versions := make([]string, 0, len(pe.VersionDetails))
+	for _, vd := range pe.VersionDetails {
+		versions = append(versions, vd.Version.Name)
+	}
+	return versions
+}
+
+// HasVersion сообщает, встречается ли в локации хоть один покемон в версии version
+func HasVersion(area pokeapi.LocationArea, version string) bool {
+	for _, pe := range area.PokemonEncounters {
+		if Appears(pe, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// details возвращает записи встреч покемона в версии version (пустая - во всех)
+func details(pe pokeapi.PokemonEncounter, version string) []pokeapi.Encounter {
+	var result []pokeapi.Encounter
+	for _, vd := range pe.VersionDetails {
+		if version == "" || vd.Version.Name == version {
+			result = append(result, vd.EncounterDetails...)
+		}
+	}
+	return result
+}
+
+// Table возвращает записи встреч локации для способа method в версии version
+// (пустая version - во всех версиях сразу, для одной встречи см. Find).
+// Варианты одного слота с разными условиями (время суток, swarm, radar...)
+// не складываются: условия не моделируются, поэтому берется вариант с наибольшим шансом
+func Table(area pokeapi.LocationArea, method, version string) []Slot {
+	var slots []Slot
+	for _, pe := range area.PokemonEncounters {
+		for _, vd := range pe.VersionDetails {
+			if version != "" && vd.Version.Name != version {
+				continue
+			}
+			// variants - индекс слота в slots по ключу slotKey
+			variants := make(map[string]int)
+			for _, detail := range vd.EncounterDetails {
+				if detail.Method.Name != method || detail.Chance <= 0 {
+					continue
+				}
+				slot := Slot{
+					Pokemon:  pe.Pokemon.Name,
+					Chance:   detail.Chance,
+					MinLevel: detail.MinLevel,
+					MaxLevel: max(detail.MaxLevel, detail.MinLevel),
+				}
+				if len(detail.ConditionValues) > 0 {
+					key := slotKey(detail)
+					if i, ok := variants[key]; ok {
+						slots[i].Chance = max(slots[i].Chance, slot.Chance)
+						continue
+					}
+					variants[key] = len(slots)
+				}
+				slots = append(slots, slot)
+			}
+		}
+	}
+	return slots
+}
+
+// slotKey - ключ слота для записи с условиями: уровни и виды условий
+// (time-morning и time-night - варианты одного условия time)
+func slotKey(detail pokeapi.Encounter) string {
+	kinds := make([]string, 0, len(detail.ConditionValues))
+	for _, cv := range detail.ConditionValues {
+		kind := cv.Name
+		if i := strings.LastIndex(kind, "-"); i > 0 {
+			kind = kind[:i]
+		}
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return fmt.Sprintf("%d-%d %s", detail.MinLevel, detail.MaxLevel, strings.Join(kinds, ","))
+}
+
+// tableVersions возвращает отсортированные версии игры, в которых в локации
+// есть встречи способа method
+func tableVersions(area pokeapi.LocationArea, method string) []string {
+	set := make(map[string]bool)
+	for _, pe := range area.PokemonEncounters {
+		for _, vd := range pe.VersionDetails {
+			for _, detail := range vd.EncounterDetails {
+				if detail.Method.Name == method && detail.Chance > 0 {
+					set[vd.Version.Name] = true
+				}
+			}
+		}
+	}
+
+	versions := make([]string, 0, len(set))
+	for version := range set {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// Methods возвращает отсортированные способы встречи, доступные в локации в версии version
+func Methods(area pokeapi.LocationArea, version string) []string {
+	set := make(map[string]bool)
+	for _, pe := range area.PokemonEncounters {
+		for _, detail := range details(pe, version) {
+			set[detail.Method.Name] = true
+		}
+	}
+
+	methods := make([]string, 0, len(set))
+	for method := range set {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Find выбирает дикого покемона локации для способа method в версии version.
+// Без версии сначала выбирается одна из версий локации, и встреча берется из ее таблицы:
+// иначе вид из нескольких игр получил бы вес за каждую.
+// Возвращает false, если встреч этим способом нет
+func Find(area pokeapi.LocationArea, method, version string, rng *rand.Rand) (Wild, bool) {
+	if version == "" {
+		if versions := tableVersions(area, method); len(versions) > 0 {
+			version = versions[rng.Intn(len(versions))]
+		}
+	}
+	return Roll(Table(area, method, version), rng)
+}
+
+// Roll выбирает запись с весом по шансу и уровень в ее диапазоне.
+// Возвращает false, если таблица пуста
+func Roll(slots []Slot, rng *rand.Rand) (Wild, bool) {
+	total := 0
+	for _, slot := range slots {
+		total += slot.Chance
+	}
+	if total == 0 {
+		return Wild{}, false
+	}
+
+	n := rng.Intn(total)
+	for _, slot := range slots {
+		if n < slot.Chance {
+			return Wild{
+				Pokemon: slot.Pokemon,
+				Level:   slot.MinLevel + rng.Intn(slot.MaxLevel-slot.MinLevel+1),
+			}, true
+		}
+		n -= slot.Chance
+	}
+	// Недостижимо: n < total
+	return Wild{}, false
+}
@@ -0,0 +1,184 @@
+package encounter
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+func testArea() pokeapi.LocationArea {
+	detail := func(method string, chance, minLevel, maxLevel int) pokeapi.Encounter {
+		return pokeapi.Encounter{Method: pokeapi.NamedResource{Name: method}, Chance: chance, MinLevel: minLevel, MaxLevel: maxLevel}
+	}
+	return pokeapi.LocationArea{
+		Name: "test-area",
+		PokemonEncounters: []pokeapi.PokemonEncounter{
+			{
+				Pokemon: pokeapi.NamedResource{Name: "rattata"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{EncounterDetails: []pokeapi.Encounter{detail("walk", 90, 2, 4)}},
+				},
+			},
+			{
+				Pokemon: pokeapi.NamedResource{Name: "pikachu"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{EncounterDetails: []pokeapi.Encounter{detail("walk", 10, 5, 5)}},
+				},
+			},
+			{
+				Pokemon: pokeapi.NamedResource{Name: "magikarp"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{EncounterDetails: []pokeapi.Encounter{detail("old-rod", 100, 5, 10)}},
+				},
+			},
+		},
+	}
+}
+
+func TestTable(t *testing.T) {
+	area := testArea()
+
+	if got := len(Table(area, "walk", "")); got != 2 {
+		t.Errorf("Expected 2 walk slots, got %d", got)
+	}
+	if slots := Table(area, "old-rod", ""); len(slots) != 1 || slots[0].Pokemon != "magikarp" {
+		t.Errorf("Expected only magikarp for old-rod, got %v", slots)
+	}
+	if got := len(Table(area, "surf", "")); got != 0 {
+		t.Errorf("Expected no surf slots, got %d", got)
+	}
+
+	methods := Methods(area, "")
+	if len(methods) != 2 || methods[0] != "old-rod" || methods[1] != "walk" {
+		t.Errorf("Expected [old-rod walk], got %v", methods)
+	}
+}
+
+func TestRollIsWeightedByChance(t *testing.T) {
+	slots := Table(testArea(), "walk", "")
+	rng := rand.New(rand.NewSource(1))
+
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		wild, ok := Roll(slots, rng)
+		if !ok {
+			t.Fatal("Expected an encounter")
+		}
+		counts[wild.Pokemon]++
+
+		switch wild.Pokemon {
+		case "rattata":
+			if wild.Level < 2 || wild.Level > 4 {
+				t.Fatalf("rattata level %d out of range 2-4", wild.Level)
+			}
+		case "pikachu":
+			if wild.Level != 5 {
+				t.Fatalf("pikachu level %d, expected 5", wild.Level)
+			}
+		}
+	}
+
+	// 90% / 10% с запасом на случайность
+	if counts["rattata"] < 8500 || counts["pikachu"] < 500 {
+		t.Errorf("Unexpected distribution: %v", counts)
+	}
+}
+
+func TestRollEmptyTable(t *testing.T) {
+	if _, ok := Roll(nil, rand.New(rand.NewSource(1))); ok {
+		t.Error("Expected no encounter for an empty table")
+	}
+}
+
+func TestVersionFilter(t *testing.T) {
+	walk := []pokeapi.Encounter{{Method: pokeapi.NamedResource{Name: "walk"}, Chance: 50, MinLevel: 3, MaxLevel: 3}}
+	area := pokeapi.LocationArea{
+		PokemonEncounters: []pokeapi.PokemonEncounter{
+			{
+				Pokemon: pokeapi.NamedResource{Name: "starly"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{Version: pokeapi.NamedResource{Name: "diamond"}, EncounterDetails: walk},
+					{Version: pokeapi.NamedResource{Name: "platinum"}, EncounterDetails: walk},
+				},
+			},
+			{
+				Pokemon: pokeapi.NamedResource{Name: "bidoof"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{Version: pokeapi.NamedResource{Name: "diamond"}, EncounterDetails: walk},
+				},
+			},
+		},
+	}
+
+	if !Appears(area.PokemonEncounters[1], "diamond") || Appears(area.PokemonEncounters[1], "platinum") {
+		t.Error("Expected bidoof only in diamond")
+	}
+	if versions := Versions(area.PokemonEncounters[0]); len(versions) != 2 || versions[1] != "platinum" {
+		t.Errorf("Expected [diamond platinum], got %v", versions)
+	}
+	if slots := Table(area, "walk", "platinum"); len(slots) != 1 || slots[0].Pokemon != "starly" {
+		t.Errorf("Expected only starly in platinum, got %v", slots)
+	}
+	if got := len(Table(area, "walk", "")); got != 3 {
+		t.Errorf("Expected 3 walk slots across versions, got %d", got)
+	}
+	if HasVersion(area, "red") || len(Methods(area, "red")) != 0 {
+		t.Error("Expected no encounters in red")
+	}
+}
+
+func TestFindWeighsEachVersionOnce(t *testing.T) {
+	walk := func(chance int, conditions ...string) pokeapi.Encounter {
+		e := pokeapi.Encounter{Method: pokeapi.NamedResource{Name: "walk"}, Chance: chance, MinLevel: 2, MaxLevel: 3}
+		for _, c := range conditions {
+			e.ConditionValues = append(e.ConditionValues, pokeapi.NamedResource{Name: c})
+		}
+		return e
+	}
+	in := func(version string, encounters ...pokeapi.Encounter) pokeapi.VersionEncounterDetail {
+		return pokeapi.VersionEncounterDetail{Version: pokeapi.NamedResource{Name: version}, EncounterDetails: encounters}
+	}
+	area := pokeapi.LocationArea{
+		PokemonEncounters: []pokeapi.PokemonEncounter{
+			{
+				// Во всех трех версиях, в каждой - варианты одного слота по времени суток
+				Pokemon: pokeapi.NamedResource{Name: "starly"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					in("diamond", walk(50, "time-morning"), walk(50, "time-day"), walk(50, "time-night")),
+					in("pearl", walk(50)),
+					in("platinum", walk(50)),
+				},
+			},
+			{
+				Pokemon:        pokeapi.NamedResource{Name: "bidoof"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{in("diamond", walk(50))},
+			},
+		},
+	}
+
+	if slots := Table(area, "walk", "diamond"); len(slots) != 2 || slots[0].Chance != 50 {
+		t.Errorf("Expected condition variants to count once, got %v", slots)
+	}
+
+	// bidoof - половина таблицы diamond и ничего в двух других версиях: 1/6.
+	// Сложение шансов по версиям дало бы ему 1/4
+	rng := rand.New(rand.NewSource(1))
+	const walks = 12000
+	bidoof := 0
+	for i := 0; i < walks; i++ {
+		wild, ok := Find(area, "walk", "", rng)
+		if !ok {
+			t.Fatal("Expected an encounter")
+		}
+		if wild.Pokemon == "bidoof" {
+			bidoof++
+		}
+	}
+	if share := float64(bidoof) / walks; share < 0.14 || share > 0.19 {
+		t.Errorf("Expected bidoof in about 1/6 of walks, got %.3f", share)
+	}
+	if wild, ok := Find(area, "walk", "pearl", rng); !ok || wild.Pokemon != "starly" {
+		t.Errorf("Expected only starly in pearl, got %+v", wild)
+	}
+}
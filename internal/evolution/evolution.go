@@ -0,0 +1,229 @@
+// Package evolution - цепочки эволюций PokeAPI: вывод дерева с условиями
+// и проверка, может ли покемон эволюционировать в этой игре.
+// Игра моделирует только уровень, время суток и локацию;
+// предметы эволюции, обмен, дружба и остальные условия пока недоступны
+package evolution
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+// Триггеры эволюции в PokeAPI
+const (
+	TriggerLevelUp = "level-up"
+	TriggerUseItem = "use-item"
+	TriggerTrade   = "trade"
+)
+
+// State - то, что игра знает о покемоне и тренере для проверки условий
+type State struct {
+	Level int
+	// HasItem сообщает, есть ли предмет в сумке; nil - предметов эволюции в игре нет
+	HasItem func(item string) bool
+	// TimeOfDay - "day", "dusk" или "night", см. TimeOfDay
+	TimeOfDay string
+	// Location - локация (не location-area), в которой сейчас тренер
+	Location string
+}
+
+// Option - один способ эволюции вида
+type Option struct {
+	Into   string
+	Detail pokeapi.EvolutionDetail
+	// Unmet - условия, которые не выполнены; пусто, если эволюция возможна
+	Unmet []string
+}
+
+// OK сообщает, выполнены ли все условия
+func (o Option) OK() bool {
+	return len(o.Unmet) == 0
+}
+
+// TimeOfDay переводит час (0-23) во время суток PokeAPI
+func TimeOfDay(hour int) string {
+	switch {
+	case hour >= 4 && hour < 17:
+		return "day"
+	case hour == 17:
+		return "dusk"
+	default:
+		return "night"
+	}
+}
+
+// Find ищет вид в цепочке
+func Find(link pokeapi.ChainLink, species string) (pokeapi.ChainLink, bool) {
+	if link.Species.Name == species {
+		return link, true
+	}
+	for _, next := range link.EvolvesTo {
+		if found, ok := Find(next, species); ok {
+			return found, true
+		}
+	}
+	return pokeapi.ChainLink{}, false
+}
+
+// Options возвращает все способы эволюции вида species с проверкой условий
+func Options(chain pokeapi.EvolutionChain, species string, s State) []Option {
+	link, ok := Find(chain.Chain, species)
+	if !ok {
+		return nil
+	}
+
+	var options []Option
+	for _, next := range link.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			options = append(options, Option{
+				Into:   next.Species.Name,
+				Detail: detail,
+				Unmet:  Check(detail, s),
+			})
+		}
+	}
+	return options
+}
+
+// Check возвращает невыполненные условия эволюции
+func Check(d pokeapi.EvolutionDetail, s State) []string {
+	var unmet []string
+
+	switch d.Trigger.Name {
+	case TriggerLevelUp:
+	case TriggerUseItem:
+		switch {
+		case s.HasItem == nil:
+			unmet = append(unmet, "evolution items are not available")
+		case d.Item == nil:
+			unmet = append(unmet, "unknown item")
+		case !s.HasItem(d.Item.Name):
+			unmet = append(unmet, "needs a "+d.Item.Name+" in the bag")
+		}
+	case TriggerTrade:
+		unmet = append(unmet, "trading is not available")
+	default:
+		unmet = append(unmet, d.Trigger.Name+" is not available")
+	}
+
+	if d.MinLevel != nil && s.Level < *d.MinLevel {
+		unmet = append(unmet, fmt.Sprintf("needs level %d (now %d)", *d.MinLevel, s.Level))
+	}
+	if d.TimeOfDay != "" && d.TimeOfDay != s.TimeOfDay {
+		unmet = append(unmet, "needs "+d.TimeOfDay+" time")
+	}
+	if d.Location != nil && d.Location.Name != s.Location {
+		unmet = append(unmet, "needs to be in "+d.Location.Name)
+	}
+	if d.MinHappiness != nil || d.MinAffection != nil {
+		unmet = append(unmet, "friendship is not available")
+	}
+	if d.MinBeauty != nil {
+		unmet = append(unmet, "beauty is not available")
+	}
+	if d.HeldItem != nil {
+		unmet = append(unmet, "held items are not available")
+	}
+	if d.KnownMove != nil {
+		unmet = append(unmet, "known moves are not available")
+	}
+	if d.Gender != nil {
+		unmet = append(unmet, "gender is not available")
+	}
+	if d.NeedsRain {
+		unmet = append(unmet, "weather is not available")
+	}
+	return unmet
+}
+
+// Describe описывает условия эволюции: "level 16", "use fire-stone", "trade holding metal-coat"
+func Describe(d pokeapi.EvolutionDetail) string {
+	var parts []string
+
+	switch d.Trigger.Name {
+	case TriggerLevelUp:
+		if d.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d", *d.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case TriggerUseItem:
+		if d.Item != nil {
+			parts = append(parts, "use "+d.Item.Name)
+		} else {
+			parts = append(parts, "use item")
+		}
+	case TriggerTrade:
+		parts = append(parts, "trade")
+		if d.TradeSpecies != nil {
+			parts = append(parts, "for "+d.TradeSpecies.Name)
+		}
+	default:
+		parts = append(parts, d.Trigger.Name)
+	}
+
+	if d.MinLevel != nil && d.Trigger.Name != TriggerLevelUp {
+		parts = append(parts, fmt.Sprintf("from level %d", *d.MinLevel))
+	}
+	if d.HeldItem != nil {
+		parts = append(parts, "holding "+d.HeldItem.Name)
+	}
+	if d.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("with friendship %d", *d.MinHappiness))
+	}
+	if d.MinAffection != nil {
+		parts = append(parts, fmt.Sprintf("with affection %d", *d.MinAffection))
+	}
+	if d.MinBeauty != nil {
+		parts = append(parts, fmt.Sprintf("with beauty %d", *d.MinBeauty))
+	}
+	if d.KnownMove != nil {
+		parts = append(parts, "knowing "+d.KnownMove.Name)
+	}
+	if d.Location != nil {
+		parts = append(parts, "at "+d.Location.Name)
+	}
+	if d.TimeOfDay != "" {
+		parts = append(parts, "at "+d.TimeOfDay)
+	}
+	if d.NeedsRain {
+		parts = append(parts, "in rain")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Format выводит цепочку деревом, у каждого вида - условия эволюции в него:
+//
+//	bulbasaur
+//	└─ ivysaur (level 16)
+//	   └─ venusaur (level 32)
+func Format(chain pokeapi.EvolutionChain) string {
+	var b strings.Builder
+	b.WriteString(chain.Chain.Species.Name)
+	b.WriteString("\n")
+	formatChildren(&b, chain.Chain, "")
+	return b.String()
+}
+
+func formatChildren(b *strings.Builder, link pokeapi.ChainLink, indent string) {
+	for i, next := range link.EvolvesTo {
+		branch, childIndent := "├─ ", "│  "
+		if i == len(link.EvolvesTo)-1 {
+			branch, childIndent = "└─ ", "   "
+		}
+
+		conditions := make([]string, 0, len(next.EvolutionDetails))
+		for _, d := range next.EvolutionDetails {
+			conditions = append(conditions, Describe(d))
+		}
+
+		b.WriteString(indent + branch + next.Species.Name)
+		if len(conditions) > 0 {
+			b.WriteString(" (" + strings.Join(conditions, " or ") + ")")
+		}
+		b.WriteString("\n")
+		formatChildren(b, next, indent+childIndent)
+	}
+}
@@ -0,0 +1,111 @@
+package evolution
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func named(name string) *pokeapi.NamedResource {
+	return &pokeapi.NamedResource{Name: name}
+}
+
+func link(species string, details []pokeapi.EvolutionDetail, next ...pokeapi.ChainLink) pokeapi.ChainLink {
+	return pokeapi.ChainLink{Species: pokeapi.NamedResource{Name: species}, EvolutionDetails: details, EvolvesTo: next}
+}
+
+func levelUp(level int) []pokeapi.EvolutionDetail {
+	return []pokeapi.EvolutionDetail{{Trigger: pokeapi.NamedResource{Name: TriggerLevelUp}, MinLevel: intPtr(level)}}
+}
+
+func eeveeChain() pokeapi.EvolutionChain {
+	return pokeapi.EvolutionChain{Chain: link("eevee", nil,
+		link("vaporeon", []pokeapi.EvolutionDetail{{Trigger: pokeapi.NamedResource{Name: TriggerUseItem}, Item: named("water-stone")}}),
+		link("espeon", []pokeapi.EvolutionDetail{{Trigger: pokeapi.NamedResource{Name: TriggerLevelUp}, MinHappiness: intPtr(160), TimeOfDay: "day"}}),
+	)}
+}
+
+func TestFormat(t *testing.T) {
+	chain := pokeapi.EvolutionChain{Chain: link("bulbasaur", nil,
+		link("ivysaur", levelUp(16),
+			link("venusaur", levelUp(32))))}
+
+	expected := "bulbasaur\n└─ ivysaur (level 16)\n   └─ venusaur (level 32)\n"
+	if got := Format(chain); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	branched := Format(eeveeChain())
+	for _, line := range []string{"├─ vaporeon (use water-stone)", "└─ espeon (level up with friendship 160 at day)"} {
+		if !strings.Contains(branched, line) {
+			t.Errorf("expected %q in:\n%s", line, branched)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	chain := pokeapi.EvolutionChain{Chain: link("charmander", nil,
+		link("charmeleon", levelUp(16),
+			link("charizard", levelUp(36))))}
+
+	options := Options(chain, "charmeleon", State{Level: 20})
+	if len(options) != 1 || options[0].Into != "charizard" || options[0].OK() {
+		t.Fatalf("expected charizard to need a higher level, got %+v", options)
+	}
+	if !strings.Contains(options[0].Unmet[0], "needs level 36 (now 20)") {
+		t.Errorf("unexpected unmet conditions %v", options[0].Unmet)
+	}
+
+	if options := Options(chain, "charmander", State{Level: 16}); len(options) != 1 || !options[0].OK() {
+		t.Errorf("expected charmander to evolve at level 16, got %+v", options)
+	}
+	if options := Options(chain, "charizard", State{Level: 100}); len(options) != 0 {
+		t.Errorf("expected no evolutions for charizard, got %+v", options)
+	}
+}
+
+func TestCheckItemsAndUnavailableTriggers(t *testing.T) {
+	bag := map[string]bool{"water-stone": true}
+	s := State{Level: 5, HasItem: func(item string) bool { return bag[item] }, TimeOfDay: "day"}
+
+	options := Options(eeveeChain(), "eevee", s)
+	if len(options) != 2 {
+		t.Fatalf("expected 2 options, got %d", len(options))
+	}
+	if !options[0].OK() {
+		t.Errorf("expected vaporeon with a water-stone in the bag, got %v", options[0].Unmet)
+	}
+	if options[1].OK() || options[1].Unmet[0] != "friendship is not available" {
+		t.Errorf("expected espeon to need friendship, got %v", options[1].Unmet)
+	}
+
+	trade := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedResource{Name: TriggerTrade}}
+	if unmet := Check(trade, s); len(unmet) != 1 || unmet[0] != "trading is not available" {
+		t.Errorf("expected trade to be unavailable, got %v", unmet)
+	}
+
+	delete(bag, "water-stone")
+	if options := Options(eeveeChain(), "eevee", s); options[0].OK() {
+		t.Error("expected vaporeon to need a water-stone")
+	}
+
+	// Без сумки с предметами эволюция предметом недоступна, как и обмен
+	s.HasItem = nil
+	if options := Options(eeveeChain(), "eevee", s); options[0].Unmet[0] != "evolution items are not available" {
+		t.Errorf("expected evolution items to be unavailable, got %v", options[0].Unmet)
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	cases := map[int]string{0: "night", 4: "day", 12: "day", 17: "dusk", 21: "night"}
+	for hour, expected := range cases {
+		if got := TimeOfDay(hour); got != expected {
+			t.Errorf("TimeOfDay(%d): expected %s, got %s", hour, expected, got)
+		}
+	}
+}
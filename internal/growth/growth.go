@@ -0,0 +1,41 @@
+// Package growth - уровни и опыт покемонов по кривым growth_rate из PokeAPI
+// и начисление опыта за победу (формула игр поколений I-IV)
+package growth
+
+import (
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+// MaxLevel - максимальный уровень покемона
+const MaxLevel = 100
+
+// Level возвращает уровень, которого достигает покемон с опытом exp
+func Level(rate pokeapi.GrowthRate, exp int) int {
+	level := 1
+	for _, l := range rate.Levels {
+		if l.Experience <= exp && l.Level > level {
+			level = l.Level
+		}
+	}
+	return min(level, MaxLevel)
+}
+
+// Experience возвращает опыт, с которого начинается уровень level
+func Experience(rate pokeapi.GrowthRate, level int) int {
+	for _, l := range rate.Levels {
+		if l.Level == level {
+			return l.Experience
+		}
+	}
+	return 0
+}
+
+// Gain возвращает опыт за победу над покемоном с базовым опытом baseExp и уровнем level.
+// За покемона тренера дают в полтора раза больше, чем за дикого
+func Gain(baseExp, level int, trainer bool) int {
+	exp := baseExp * level / 7
+	if trainer {
+		exp = exp * 3 / 2
+	}
+	return max(exp, 1)
+}
@@ -0,0 +1,48 @@
+package growth
+
+import (
+	"testing"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+// mediumFast - начало кривой medium (опыт = уровень^3)
+func mediumFast() pokeapi.GrowthRate {
+	rate := pokeapi.GrowthRate{Name: "medium"}
+	for level := 1; level <= 10; level++ {
+		rate.Levels = append(rate.Levels, pokeapi.GrowthRateLevel{Level: level, Experience: level * level * level})
+	}
+	return rate
+}
+
+func TestLevel(t *testing.T) {
+	rate := mediumFast()
+	cases := map[int]int{0: 1, 1: 1, 7: 1, 8: 2, 124: 4, 125: 5, 1000: 10, 5000: 10}
+	for exp, expected := range cases {
+		if got := Level(rate, exp); got != expected {
+			t.Errorf("Level(%d): expected %d, got %d", exp, expected, got)
+		}
+	}
+}
+
+func TestExperience(t *testing.T) {
+	rate := mediumFast()
+	if got := Experience(rate, 5); got != 125 {
+		t.Errorf("expected 125, got %d", got)
+	}
+	if got := Experience(rate, 50); got != 0 {
+		t.Errorf("expected 0 for a level missing from the curve, got %d", got)
+	}
+}
+
+func TestGain(t *testing.T) {
+	if got := Gain(64, 7, false); got != 64 {
+		t.Errorf("expected 64 for a wild pokemon, got %d", got)
+	}
+	if got := Gain(64, 7, true); got != 96 {
+		t.Errorf("expected 96 for a trainer's pokemon, got %d", got)
+	}
+	if got := Gain(0, 1, false); got != 1 {
+		t.Errorf("expected at least 1, got %d", got)
+	}
+}
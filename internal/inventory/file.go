@@ -0,0 +1,63 @@
+package inventory
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// inventoryFile - формат файла сумки. Предметы с нулевым количеством
+// тоже сохраняются, чтобы пустая сумка не превращалась в стартовую
+type inventoryFile struct {
+	Items map[string]int `json:"items"`
+}
+
+// Load читает сумку из файла. Если файла нет, ошибка - fs.ErrNotExist
+func Load(path string) (*Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file inventoryFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	inv := New()
+	for name, count := range file.Items {
+		inv.items[name] = count
+	}
+	return inv, nil
+}
+
+// Save атомарно записывает сумку в файл: во временный файл рядом и Rename
+func (i *Inventory) Save(path string) error {
+	i.mu.Lock()
+	data, err := json.MarshalIndent(inventoryFile{Items: i.items}, "", "  ")
+	i.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// После успешного Rename удалять уже нечего
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
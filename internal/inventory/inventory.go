@@ -0,0 +1,119 @@
+// Package inventory - сумка тренера: покеболы и другие предметы с ограниченным количеством
+package inventory
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Ball - вид покебола. Modifier - множитель поимки в формуле игр
+type Ball struct {
+	Name     string
+	Label    string
+	Modifier float64
+}
+
+// Balls - все покеболы от простого к лучшему
+var Balls = []Ball{
+	{Name: "poke-ball", Label: "Poké Ball", Modifier: 1},
+	{Name: "great-ball", Label: "Great Ball", Modifier: 1.5},
+	{Name: "ultra-ball", Label: "Ultra Ball", Modifier: 2},
+	// Master Ball ловит любого покемона
+	{Name: "master-ball", Label: "Master Ball", Modifier: 255},
+}
+
+// DefaultBall - покебол, который бросается, если другой не указан
+var DefaultBall = Balls[0]
+
+// LookupBall ищет покебол по имени: "great-ball", "great" или "greatball"
+func LookupBall(name string) (Ball, bool) {
+	name = strings.ToLower(strings.TrimSuffix(strings.TrimSuffix(name, "-ball"), "ball"))
+	for _, ball := range Balls {
+		if strings.TrimSuffix(ball.Name, "-ball") == name {
+			return ball, true
+		}
+	}
+	return Ball{}, false
+}
+
+// Item - предмет и его количество
+type Item struct {
+	Name  string
+	Count int
+}
+
+type Inventory struct {
+	mu    *sync.Mutex
+	items map[string]int
+}
+
+func New() *Inventory {
+	return &Inventory{
+		mu:    &sync.Mutex{},
+		items: make(map[string]int),
+	}
+}
+
+// NewStarter возвращает сумку начинающего тренера
+func NewStarter() *Inventory {
+	inv := New()
+	inv.Add("poke-ball", 20)
+	inv.Add("great-ball", 10)
+	inv.Add("ultra-ball", 5)
+	inv.Add("master-ball", 1)
+	return inv
+}
+
+// Add добавляет n предметов
+func (i *Inventory) Add(item string, n int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.items[item] += n
+}
+
+// Count возвращает количество предмета
+func (i *Inventory) Count(item string) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.items[item]
+}
+
+// Use тратит один предмет, возвращает false если их не осталось
+func (i *Inventory) Use(item string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.items[item] <= 0 {
+		return false
+	}
+	i.items[item]--
+	return true
+}
+
+// Items возвращает все предметы: сначала покеболы по порядку Balls, затем остальные по имени
+func (i *Inventory) Items() []Item {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	rank := func(name string) int {
+		for r, ball := range Balls {
+			if ball.Name == name {
+				return r
+			}
+		}
+		return len(Balls)
+	}
+
+	items := make([]Item, 0, len(i.items))
+	for name, count := range i.items {
+		items = append(items, Item{Name: name, Count: count})
+	}
+	sort.Slice(items, func(a, b int) bool {
+		ra, rb := rank(items[a].Name), rank(items[b].Name)
+		if ra != rb {
+			return ra < rb
+		}
+		return items[a].Name < items[b].Name
+	})
+	return items
+}
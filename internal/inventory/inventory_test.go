@@ -0,0 +1,74 @@
+package inventory
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookupBall(t *testing.T) {
+	for _, name := range []string{"great", "great-ball", "greatball", "Great"} {
+		ball, ok := LookupBall(name)
+		if !ok || ball.Name != "great-ball" || ball.Modifier != 1.5 {
+			t.Errorf("LookupBall(%q): unexpected %+v, %v", name, ball, ok)
+		}
+	}
+	if _, ok := LookupBall("heavy"); ok {
+		t.Errorf("expected unknown ball to be rejected")
+	}
+}
+
+func TestUse(t *testing.T) {
+	inv := New()
+	inv.Add("ultra-ball", 1)
+
+	if !inv.Use("ultra-ball") {
+		t.Fatalf("expected to use an ultra ball")
+	}
+	if inv.Use("ultra-ball") {
+		t.Errorf("expected no ultra balls left")
+	}
+	if inv.Count("ultra-ball") != 0 {
+		t.Errorf("expected count 0, got %d", inv.Count("ultra-ball"))
+	}
+}
+
+func TestItemsOrder(t *testing.T) {
+	inv := NewStarter()
+	inv.Add("fire-stone", 1)
+
+	expected := []string{"poke-ball", "great-ball", "ultra-ball", "master-ball", "fire-stone"}
+	items := inv.Items()
+	if len(items) != len(expected) {
+		t.Fatalf("expected %d items, got %v", len(expected), items)
+	}
+	for i, name := range expected {
+		if items[i].Name != name {
+			t.Errorf("expected %s at position %d, got %s", name, i, items[i].Name)
+		}
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.bag.json")
+	if _, err := Load(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected fs.ErrNotExist for a missing bag, got %v", err)
+	}
+
+	inv := New()
+	inv.Add("poke-ball", 3)
+	inv.Add("master-ball", 1)
+	inv.Use("master-ball")
+	if err := inv.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Count("poke-ball") != 3 || loaded.Count("master-ball") != 0 {
+		t.Errorf("unexpected bag after load: %v", loaded.Items())
+	}
+}
@@ -0,0 +1,272 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+// BaseURL - адрес PokeAPI по умолчанию
+const BaseURL = "https://pokeapi.co/api/v2/"
+
+// Client - клиент PokeAPI со своим базовым адресом, таймаутом и кэшем
+type Client struct {
+	baseURL    string
+	httpClient http.Client
+	cache      pokecache.Cache
+	// quiet - не печатать сообщения о кэше, см. Quiet
+	quiet bool
+
+	// offline - локальная выгрузка api-data; если задана, сеть не используется
+	offline *offlineSource
+}
+
+// NewClient создает клиент. baseURL должен заканчиваться на "/",
+// cache может быть nil - тогда ответы не кэшируются
+func NewClient(baseURL string, timeout time.Duration, cache pokecache.Cache) Client {
+	return Client{
+		baseURL: baseURL,
+		httpClient: http.Client{
+			Timeout: timeout,
+		},
+		cache: cache,
+	}
+}
+
+// NewOfflineClient создает клиент, который читает ресурсы из локальной
+// выгрузки PokeAPI (репозиторий api-data): dataDir/api/v2/pokemon/pikachu/index.json и т.д.
+func NewOfflineClient(dataDir string) Client {
+	return Client{
+		offline: &offlineSource{dir: dataDir},
+	}
+}
+
+// Quiet возвращает копию клиента с тем же кэшем, которая не печатает
+// сообщения о кэше - для служебных запросов посреди чужого вывода
+func (c Client) Quiet() Client {
+	c.quiet = true
+	return c
+}
+
+// ListLocationAreas возвращает страницу списка location-area
+func (c *Client) ListLocationAreas(offset, limit int) (LocationAreaList, error) {
+	var list LocationAreaList
+
+	data, err := c.getList("location-area", offset, limit)
+	if err != nil {
+		return list, err
+	}
+
+	err = json.Unmarshal(data, &list)
+	return list, err
+}
+
+// GetLocationArea возвращает location-area по имени или id
+func (c *Client) GetLocationArea(name string) (LocationArea, error) {
+	var area LocationArea
+
+	data, err := c.getResource("location-area", name)
+	if err != nil {
+		return area, err
+	}
+
+	err = json.Unmarshal(data, &area)
+	return area, err
+}
+
+// GetPokemon возвращает покемона по имени или id
+func (c *Client) GetPokemon(name string) (Pokemon, error) {
+	var pokemon Pokemon
+
+	data, err := c.getResource("pokemon", name)
+	if err != nil {
+		return pokemon, err
+	}
+
+	err = json.Unmarshal(data, &pokemon)
+	return pokemon, err
+}
+
+// GetMove возвращает прием по имени или id
+func (c *Client) GetMove(name string) (Move, error) {
+	var move Move
+
+	data, err := c.getResource("move", name)
+	if err != nil {
+		return move, err
+	}
+
+	err = json.Unmarshal(data, &move)
+	return move, err
+}
+
+// GetPokemonSpecies возвращает вид покемона по имени или id
+func (c *Client) GetPokemonSpecies(name string) (PokemonSpecies, error) {
+	var species PokemonSpecies
+
+	data, err := c.getResource("pokemon-species", name)
+	if err != nil {
+		return species, err
+	}
+
+	err = json.Unmarshal(data, &species)
+	return species, err
+}
+
+// GetVersion возвращает версию игры по имени или id
+func (c *Client) GetVersion(name string) (Version, error) {
+	var version Version
+
+	data, err := c.getResource("version", name)
+	if err != nil {
+		return version, err
+	}
+
+	err = json.Unmarshal(data, &version)
+	return version, err
+}
+
+// ListPokemonSpecies возвращает страницу списка видов покемонов.
+// Count в ответе - общее число видов
+func (c *Client) ListPokemonSpecies(offset, limit int) (NamedResourceList, error) {
+	var list NamedResourceList
+
+	data, err := c.getList("pokemon-species", offset, limit)
+	if err != nil {
+		return list, err
+	}
+
+	err = json.Unmarshal(data, &list)
+	return list, err
+}
+
+// GetEvolutionChain возвращает цепочку эволюций по id или по url
+// из PokemonSpecies.EvolutionChain
+func (c *Client) GetEvolutionChain(ref string) (EvolutionChain, error) {
+	var chain EvolutionChain
+
+	data, err := c.getResource("evolution-chain", path.Base(strings.TrimSuffix(ref, "/")))
+	if err != nil {
+		return chain, err
+	}
+
+	err = json.Unmarshal(data, &chain)
+	return chain, err
+}
+
+// GetGrowthRate возвращает кривую опыта по имени (slow, medium, fast...) или id
+func (c *Client) GetGrowthRate(name string) (GrowthRate, error) {
+	var rate GrowthRate
+
+	data, err := c.getResource("growth-rate", name)
+	if err != nil {
+		return rate, err
+	}
+
+	err = json.Unmarshal(data, &rate)
+	return rate, err
+}
+
+// typeListLimit - с запасом больше числа типов в PokeAPI, чтобы получить их одной страницей
+const typeListLimit = 100
+
+// ListTypes возвращает все типы
+func (c *Client) ListTypes() (NamedResourceList, error) {
+	var list NamedResourceList
+
+	data, err := c.getList("type", 0, typeListLimit)
+	if err != nil {
+		return list, err
+	}
+
+	err = json.Unmarshal(data, &list)
+	return list, err
+}
+
+// GetType возвращает тип по имени или id
+func (c *Client) GetType(name string) (Type, error) {
+	var t Type
+
+	data, err := c.getResource("type", name)
+	if err != nil {
+		return t, err
+	}
+
+	err = json.Unmarshal(data, &t)
+	return t, err
+}
+
+// getList возвращает страницу списка ресурсов
+func (c *Client) getList(resource string, offset, limit int) ([]byte, error) {
+	if c.offline != nil {
+		return c.offline.list(resource, offset, limit)
+	}
+	return c.getCached(fmt.Sprintf("%s%s/?offset=%d&limit=%d", c.baseURL, resource, offset, limit))
+}
+
+// getResource возвращает один ресурс по имени или id
+func (c *Client) getResource(resource, name string) ([]byte, error) {
+	if c.offline != nil {
+		return c.offline.get(resource, name)
+	}
+	return c.getCached(c.baseURL + resource + "/" + name)
+}
+
+// getCached возвращает тело ответа из кэша, а при промахе
+// выполняет запрос и кэширует ответ под его url.
+// В кэше лежит исходный JSON, поэтому каждый метод декодирует его в свой тип
+func (c *Client) getCached(url string) ([]byte, error) {
+	// Проверяем кэш
+	if c.cache != nil {
+		if cachedData, found := c.cache.Get(url); found {
+			c.logCache("...USING CACHE DATA...")
+			return cachedData, nil
+		}
+	}
+
+	data, err := c.get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сохраняем в кэш
+	if c.cache != nil {
+		c.cache.Add(url, data)
+		c.logCache("...DATA CACHED...")
+	}
+
+	return data, nil
+}
+
+// logCache печатает сообщение о кэше, если клиент не Quiet
+func (c *Client) logCache(msg string) {
+	if !c.quiet {
+		fmt.Println(msg)
+	}
+}
+
+// get выполняет GET запрос и возвращает тело ответа
+func (c *Client) get(url string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error: %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,143 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+func TestListLocationAreasUsesCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/location-area/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("offset") != "20" || r.URL.Query().Get("limit") != "10" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(LocationAreaList{
+			Count:   1,
+			Results: []NamedResource{{Name: "canalave-city-area"}},
+		})
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Stop()
+	client := NewClient(server.URL+"/", time.Second, cache)
+
+	for i := 0; i < 2; i++ {
+		list, err := client.ListLocationAreas(20, 10)
+		if err != nil {
+			t.Fatalf("ListLocationAreas failed: %v", err)
+		}
+		if len(list.Results) != 1 || list.Results[0].Name != "canalave-city-area" {
+			t.Errorf("unexpected results %v", list.Results)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestGetPokemon(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon/pikachu" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"name":"pikachu","base_experience":112,"types":[{"slot":1,"type":{"name":"electric"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", time.Second, nil)
+
+	pokemon, err := client.GetPokemon("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon failed: %v", err)
+	}
+	if pokemon.BaseExperience != 112 || pokemon.Types[0].Type.Name != "electric" {
+		t.Errorf("unexpected pokemon %+v", pokemon)
+	}
+
+	if _, err := client.GetPokemon("missingno"); err == nil {
+		t.Error("expected error for 404 response")
+	}
+}
+
+func TestGetLocationAreaAndPokemonUseCache(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/location-area/canalave-city-area":
+			w.Write([]byte(`{"name":"canalave-city-area","pokemon_encounters":[{"pokemon":{"name":"tentacool"}}]}`))
+		case "/pokemon/tentacool":
+			w.Write([]byte(`{"name":"tentacool","base_experience":67}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Stop()
+	client := NewClient(server.URL+"/", time.Second, cache)
+
+	for i := 0; i < 2; i++ {
+		area, err := client.GetLocationArea("canalave-city-area")
+		if err != nil {
+			t.Fatalf("GetLocationArea failed: %v", err)
+		}
+		if len(area.PokemonEncounters) != 1 || area.PokemonEncounters[0].Pokemon.Name != "tentacool" {
+			t.Errorf("unexpected encounters %v", area.PokemonEncounters)
+		}
+
+		pokemon, err := client.GetPokemon("tentacool")
+		if err != nil {
+			t.Fatalf("GetPokemon failed: %v", err)
+		}
+		if pokemon.BaseExperience != 67 {
+			t.Errorf("unexpected pokemon %+v", pokemon)
+		}
+	}
+
+	for path, count := range requests {
+		if count != 1 {
+			t.Errorf("expected 1 request to %s, got %d", path, count)
+		}
+	}
+}
+
+func TestGetEvolutionChainByURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/evolution-chain/10" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":10,"chain":{"species":{"name":"pichu"},"evolution_details":[],"evolves_to":[
+			{"species":{"name":"pikachu"},"evolution_details":[{"trigger":{"name":"level-up"},"min_level":null,"min_happiness":220,"item":null}],"evolves_to":[]}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", time.Second, nil)
+
+	chain, err := client.GetEvolutionChain("https://pokeapi.co/api/v2/evolution-chain/10/")
+	if err != nil {
+		t.Fatalf("GetEvolutionChain failed: %v", err)
+	}
+	pikachu := chain.Chain.EvolvesTo[0]
+	if pikachu.Species.Name != "pikachu" {
+		t.Fatalf("unexpected chain %+v", chain)
+	}
+	detail := pikachu.EvolutionDetails[0]
+	if detail.MinLevel != nil || detail.MinHappiness == nil || *detail.MinHappiness != 220 {
+		t.Errorf("unexpected evolution details %+v", detail)
+	}
+}
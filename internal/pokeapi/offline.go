@@ -0,0 +1,91 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// offlineSource читает ресурсы из выгрузки api-data.
+// В ней ресурсы лежат в каталогах по id (pokemon/25/index.json),
+// поэтому имя при необходимости переводится в id через список ресурса
+type offlineSource struct {
+	dir string
+}
+
+// resourceIndex - файл api/v2/<resource>/index.json со всеми ресурсами
+type resourceIndex struct {
+	Count   int             `json:"count"`
+	Results []NamedResource `json:"results"`
+}
+
+func (o *offlineSource) file(parts ...string) string {
+	elems := append([]string{o.dir, "api", "v2"}, parts...)
+	return filepath.Join(append(elems, "index.json")...)
+}
+
+func (o *offlineSource) readIndex(resource string) (resourceIndex, error) {
+	var index resourceIndex
+
+	data, err := os.ReadFile(o.file(resource))
+	if err != nil {
+		return index, fmt.Errorf("offline data for %s not found: %w", resource, err)
+	}
+	err = json.Unmarshal(data, &index)
+	return index, err
+}
+
+// get читает ресурс по имени или id
+func (o *offlineSource) get(resource, name string) ([]byte, error) {
+	data, err := os.ReadFile(o.file(resource, name))
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	// Ищем id ресурса по имени в списке
+	index, err := o.readIndex(resource)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range index.Results {
+		if r.Name == name {
+			id := path.Base(strings.TrimSuffix(r.URL, "/"))
+			return os.ReadFile(o.file(resource, id))
+		}
+	}
+
+	return nil, fmt.Errorf("%s %q not found in offline data", resource, name)
+}
+
+// list собирает страницу списка так же, как ее отдает PokeAPI
+func (o *offlineSource) list(resource string, offset, limit int) ([]byte, error) {
+	index, err := o.readIndex(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	page := NamedResourceList{Count: len(index.Results)}
+
+	start := min(offset, len(index.Results))
+	end := min(offset+limit, len(index.Results))
+	page.Results = index.Results[start:end]
+
+	if end < len(index.Results) {
+		next := fmt.Sprintf("/api/v2/%s/?offset=%d&limit=%d", resource, end, limit)
+		page.Next = &next
+	}
+	if start > 0 {
+		previous := fmt.Sprintf("/api/v2/%s/?offset=%d&limit=%d", resource, max(start-limit, 0), limit)
+		page.Previous = &previous
+	}
+
+	return json.Marshal(page)
+}
@@ -0,0 +1,71 @@
+package pokeapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFixture кладет файл index.json в выгрузку api-data
+func writeFixture(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, "api", "v2", rel, "index.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOfflineClient(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "location-area", `{"count":3,"results":[
+		{"name":"area-1","url":"/api/v2/location-area/1/"},
+		{"name":"area-2","url":"/api/v2/location-area/2/"},
+		{"name":"area-3","url":"/api/v2/location-area/3/"}]}`)
+	writeFixture(t, dir, "location-area/2", `{"id":2,"name":"area-2","pokemon_encounters":[{"pokemon":{"name":"pikachu"}}]}`)
+	writeFixture(t, dir, "pokemon/pikachu", `{"id":25,"name":"pikachu","base_experience":112}`)
+
+	client := NewOfflineClient(dir)
+
+	page, err := client.ListLocationAreas(1, 1)
+	if err != nil {
+		t.Fatalf("ListLocationAreas failed: %v", err)
+	}
+	if page.Count != 3 || len(page.Results) != 1 || page.Results[0].Name != "area-2" {
+		t.Errorf("unexpected page %+v", page)
+	}
+	if page.Next == nil || page.Previous == nil {
+		t.Errorf("expected both next and previous for a middle page")
+	}
+
+	last, err := client.ListLocationAreas(2, 5)
+	if err != nil {
+		t.Fatalf("ListLocationAreas failed: %v", err)
+	}
+	if last.Next != nil || len(last.Results) != 1 {
+		t.Errorf("unexpected last page %+v", last)
+	}
+
+	// location-area лежит по id, имя находится через список
+	area, err := client.GetLocationArea("area-2")
+	if err != nil {
+		t.Fatalf("GetLocationArea failed: %v", err)
+	}
+	if len(area.PokemonEncounters) != 1 || area.PokemonEncounters[0].Pokemon.Name != "pikachu" {
+		t.Errorf("unexpected area %+v", area)
+	}
+
+	pokemon, err := client.GetPokemon("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon failed: %v", err)
+	}
+	if pokemon.BaseExperience != 112 {
+		t.Errorf("unexpected pokemon %+v", pokemon)
+	}
+
+	if _, err := client.GetPokemon("mewtwo"); err == nil {
+		t.Error("expected error for missing offline resource")
+	}
+}
@@ -0,0 +1,193 @@
+package pokeapi
+
+// NamedResource - ссылка на ресурс PokeAPI (имя + url)
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// NamedResourceList - одна страница списка ресурсов
+type NamedResourceList struct {
+	Count    int             `json:"count"`
+	Next     *string         `json:"next"`
+	Previous *string         `json:"previous"`
+	Results  []NamedResource `json:"results"`
+}
+
+// LocationAreaList - страница списка location-area
+type LocationAreaList = NamedResourceList
+
+type LocationArea struct {
+	EncounterMethodRates []any              `json:"encounter_method_rates"`
+	GameIndex            int                `json:"game_index"`
+	ID                   int                `json:"id"`
+	Location             NamedResource      `json:"location"`
+	Name                 string             `json:"name"`
+	Names                []any              `json:"names"`
+	PokemonEncounters    []PokemonEncounter `json:"pokemon_encounters"`
+}
+
+type PokemonEncounter struct {
+	Pokemon        NamedResource            `json:"pokemon"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+// VersionEncounterDetail - встречи покемона в локации в одной версии игры
+type VersionEncounterDetail struct {
+	Version NamedResource `json:"version"`
+	// MaxChance - суммарный шанс встречи в этой версии, %
+	MaxChance        int         `json:"max_chance"`
+	EncounterDetails []Encounter `json:"encounter_details"`
+}
+
+// Encounter - способ встречи (walk, surf, old-rod...), его шанс и диапазон уровней
+type Encounter struct {
+	MinLevel        int             `json:"min_level"`
+	MaxLevel        int             `json:"max_level"`
+	ConditionValues []NamedResource `json:"condition_values"`
+	Chance          int             `json:"chance"`
+	Method          NamedResource   `json:"method"`
+}
+
+type Pokemon struct {
+	ID                     int           `json:"id"`
+	Name                   string        `json:"name"`
+	BaseExperience         int           `json:"base_experience"`
+	Height                 int           `json:"height"`
+	IsDefault              bool          `json:"is_default"`
+	Order                  int           `json:"order"`
+	Weight                 int           `json:"weight"`
+	Abilities              []any         `json:"abilities"`
+	Forms                  []any         `json:"forms"`
+	GameIndices            []any         `json:"game_indices"`
+	HeldItems              []any         `json:"held_items"`
+	LocationAreaEncounters string        `json:"location_area_encounters"`
+	Moves                  []PokemonMove `json:"moves"`
+	Species                NamedResource `json:"species"`
+	Sprites                any           `json:"sprites"`
+	Cries                  any           `json:"cries"`
+	Stats                  []PokemonStat `json:"stats"`
+	Types                  []PokemonType `json:"types"`
+	PastTypes              []any         `json:"past_types"`
+	PastAbilities          []any         `json:"past_abilities"`
+}
+
+type PokemonStat struct {
+	BaseStat int           `json:"base_stat"`
+	Effort   int           `json:"effort"`
+	Stat     NamedResource `json:"stat"`
+}
+
+type PokemonType struct {
+	Slot int           `json:"slot"`
+	Type NamedResource `json:"type"`
+}
+
+type PokemonMove struct {
+	Move                NamedResource `json:"move"`
+	VersionGroupDetails []any         `json:"version_group_details"`
+}
+
+// Move - прием. Power и Accuracy равны nil у приемов без урона
+// и у приемов, которые не промахиваются
+type Move struct {
+	ID          int           `json:"id"`
+	Name        string        `json:"name"`
+	Accuracy    *int          `json:"accuracy"`
+	Power       *int          `json:"power"`
+	PP          int           `json:"pp"`
+	Priority    int           `json:"priority"`
+	Type        NamedResource `json:"type"`
+	DamageClass NamedResource `json:"damage_class"`
+}
+
+// Type - тип покемона и его отношения урона с другими типами
+type Type struct {
+	ID              int             `json:"id"`
+	Name            string          `json:"name"`
+	DamageRelations DamageRelations `json:"damage_relations"`
+}
+
+type DamageRelations struct {
+	DoubleDamageFrom []NamedResource `json:"double_damage_from"`
+	DoubleDamageTo   []NamedResource `json:"double_damage_to"`
+	HalfDamageFrom   []NamedResource `json:"half_damage_from"`
+	HalfDamageTo     []NamedResource `json:"half_damage_to"`
+	NoDamageFrom     []NamedResource `json:"no_damage_from"`
+	NoDamageTo       []NamedResource `json:"no_damage_to"`
+}
+
+// PokemonSpecies - вид покемона (общие данные всех его форм)
+type PokemonSpecies struct {
+	ID             int            `json:"id"`
+	Name           string         `json:"name"`
+	CaptureRate    int            `json:"capture_rate"`
+	BaseHappiness  int            `json:"base_happiness"`
+	IsLegendary    bool           `json:"is_legendary"`
+	IsMythical     bool           `json:"is_mythical"`
+	GrowthRate     NamedResource  `json:"growth_rate"`
+	EvolvesFrom    *NamedResource `json:"evolves_from_species"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	// Varieties - покемоны (формы) вида, у одного из них IsDefault
+	Varieties []PokemonVariety `json:"varieties"`
+}
+
+// PokemonVariety - форма вида: wormadam-plant у вида wormadam
+type PokemonVariety struct {
+	IsDefault bool          `json:"is_default"`
+	Pokemon   NamedResource `json:"pokemon"`
+}
+
+// Version - версия игры (red, gold, platinum...)
+type Version struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	VersionGroup NamedResource `json:"version_group"`
+}
+
+// EvolutionChain - цепочка эволюций, общая для всех видов в ней
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+// ChainLink - вид в цепочке, условия эволюции в него и следующие виды
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedResource     `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail - условия одного способа эволюции.
+// Незаданные условия в PokeAPI равны null, поэтому поля - указатели
+type EvolutionDetail struct {
+	Trigger      NamedResource  `json:"trigger"`
+	Item         *NamedResource `json:"item"`
+	HeldItem     *NamedResource `json:"held_item"`
+	KnownMove    *NamedResource `json:"known_move"`
+	Location     *NamedResource `json:"location"`
+	TradeSpecies *NamedResource `json:"trade_species"`
+	MinLevel     *int           `json:"min_level"`
+	MinHappiness *int           `json:"min_happiness"`
+	MinAffection *int           `json:"min_affection"`
+	MinBeauty    *int           `json:"min_beauty"`
+	Gender       *int           `json:"gender"`
+	TimeOfDay    string         `json:"time_of_day"`
+	NeedsRain    bool           `json:"needs_overworld_rain"`
+}
+
+// GrowthRate - кривая опыта: сколько опыта нужно для каждого уровня
+type GrowthRate struct {
+	ID      int               `json:"id"`
+	Name    string            `json:"name"`
+	Formula string            `json:"formula"`
+	Levels  []GrowthRateLevel `json:"levels"`
+}
+
+type GrowthRateLevel struct {
+	Level      int `json:"level"`
+	Experience int `json:"experience"`
+}
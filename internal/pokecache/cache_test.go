@@ -2,6 +2,9 @@ package pokecache
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -59,3 +62,231 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestDiskTierSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	cache := NewCache(time.Minute, WithDisk(dir, time.Hour))
+	cache.Add("https://example.com", []byte("testdata"))
+	cache.Stop()
+
+	restarted := NewCache(time.Minute, WithDisk(dir, time.Hour))
+	defer restarted.Stop()
+
+	val, ok := restarted.Get("https://example.com")
+	if !ok {
+		t.Fatalf("expected to find key after restart")
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected testdata, got %s", val)
+	}
+}
+
+func TestDiskTierLoadsLazily(t *testing.T) {
+	dir := t.TempDir()
+
+	cache := NewCache(time.Minute, WithDisk(dir, time.Hour))
+	cache.Add("https://example.com", []byte("testdata"))
+	cache.Stop()
+	before, err := os.ReadFile(filepath.Join(dir, mustSingleFile(t, dir)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := NewCache(time.Minute, WithDisk(dir, time.Hour))
+	defer restarted.Stop()
+
+	if keys := restarted.Keys(); len(keys) != 0 {
+		t.Errorf("expected nothing in memory before Get, got %+v", keys)
+	}
+	if _, ok := restarted.Get("https://example.com"); !ok {
+		t.Fatalf("expected Get to read the key from disk")
+	}
+
+	// Чтение с диска не переписывает запись и ее created_at
+	after, err := os.ReadFile(filepath.Join(dir, mustSingleFile(t, dir)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Errorf("expected the disk entry to stay unchanged")
+	}
+}
+
+func TestDiskTierKeepsForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	foreign := map[string]string{
+		"pokedex.json": `{"pokemon":[]}`,
+		"notes.json":   `{"key":"x","val":"eA=="}`,
+		// Имя записи, но содержимое не наше
+		strings.Repeat("a", 64) + ".entry": "not json",
+	}
+	for name, data := range foreign {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache := NewCache(5*time.Millisecond, WithDisk(dir, 5*time.Millisecond))
+	defer cache.Stop()
+	cache.Add("https://example.com", []byte("testdata"))
+	time.Sleep(50 * time.Millisecond)
+
+	// Сборщик удаляет только истекшие записи, которые смог прочитать
+	for name := range foreign {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to survive reaping, got %v", name, err)
+		}
+	}
+	if files, _ := os.ReadDir(dir); len(files) != len(foreign) {
+		t.Errorf("expected the expired entry to be reaped, got %d files", len(files))
+	}
+
+	// Clear удаляет все файлы записей, но не чужие файлы
+	cache.Clear()
+	for _, name := range []string{"pokedex.json", "notes.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to survive Clear, got %v", name, err)
+		}
+	}
+}
+
+// mustSingleFile возвращает имя единственного файла в каталоге
+func mustSingleFile(t *testing.T, dir string) string {
+	t.Helper()
+	files, err := os.ReadDir(dir)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one file in %s, got %d (%v)", dir, len(files), err)
+	}
+	return files[0].Name()
+}
+
+func TestDiskTierOutlivesMemory(t *testing.T) {
+	const interval = 5 * time.Millisecond
+	cache := NewCache(interval, WithDisk(t.TempDir(), time.Hour))
+	defer cache.Stop()
+	cache.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(interval * 3)
+
+	if _, ok := cache.Get("https://example.com"); !ok {
+		t.Errorf("expected disk tier to serve key reaped from memory")
+	}
+}
+
+func TestDiskTierReapsExpiredEntries(t *testing.T) {
+	dir := t.TempDir()
+	const diskTTL = 10 * time.Millisecond
+
+	cache := NewCache(5*time.Millisecond, WithDisk(dir, diskTTL))
+	defer cache.Stop()
+	cache.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(diskTTL * 4)
+
+	if _, ok := cache.Get("https://example.com"); ok {
+		t.Errorf("expected expired key to be gone")
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected expired files to be removed, got %d", len(files))
+	}
+}
+
+func TestMaxEntriesEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache(time.Minute, WithMaxEntries(2))
+	defer cache.Stop()
+
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+	// "a" становится недавно использованным, вытеснен должен быть "b"
+	cache.Get("a")
+	cache.Add("c", []byte("3"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to stay in cache", key)
+		}
+	}
+	if cache.Evictions() != 1 {
+		t.Errorf("expected 1 eviction, got %d", cache.Evictions())
+	}
+}
+
+func TestMaxBytesEvictsUntilWithinLimit(t *testing.T) {
+	cache := NewCache(time.Minute, WithMaxBytes(10))
+	defer cache.Stop()
+
+	cache.Add("a", []byte("12345"))
+	cache.Add("b", []byte("12345"))
+	cache.Add("c", []byte("123456789"))
+
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected a to be evicted")
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Errorf("expected c to stay in cache")
+	}
+	if cache.Evictions() != 2 {
+		t.Errorf("expected 2 evictions, got %d", cache.Evictions())
+	}
+}
+
+func TestStats(t *testing.T) {
+	cache := NewCache(time.Minute, WithMaxEntries(2))
+	defer cache.Stop()
+
+	cache.Add("a", []byte("12"))
+	cache.Add("b", []byte("345"))
+	cache.Get("a")
+	cache.Get("missing")
+	cache.Add("c", []byte("6"))
+
+	stats := cache.Stats()
+	expected := Stats{Hits: 1, Misses: 1, Evictions: 1, Entries: 2, Bytes: 3}
+	if stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestKeysDeleteClear(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(time.Minute, WithDisk(dir, time.Hour))
+	defer cache.Stop()
+
+	cache.Add("b", []byte("12345"))
+	cache.Add("a", []byte("1"))
+
+	keys := cache.Keys()
+	if len(keys) != 2 || keys[0].Key != "a" || keys[1].Key != "b" || keys[1].Size != 5 {
+		t.Errorf("unexpected keys %+v", keys)
+	}
+
+	if !cache.Delete("a") {
+		t.Errorf("expected Delete to remove a")
+	}
+	if cache.Delete("a") {
+		t.Errorf("expected second Delete to return false")
+	}
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected a to be gone from memory and disk")
+	}
+
+	cache.Clear()
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be gone after Clear")
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 0 {
+		t.Errorf("expected disk to be empty after Clear, got %d files", len(files))
+	}
+}
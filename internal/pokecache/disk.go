@@ -0,0 +1,143 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// diskTier хранит записи кэша файлами <sha256 ключа>.entry в каталоге dir.
+// Каталог может быть общим с другими файлами (например, -cache-dir .),
+// поэтому трогаются только файлы с таким именем.
+// Ошибки диска не считаются фатальными: кэш просто работает как кэш в памяти
+type diskTier struct {
+	dir string
+	ttl time.Duration
+}
+
+// diskEntry - формат файла одной записи
+type diskEntry struct {
+	Key       string    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+	Val       []byte    `json:"val"`
+}
+
+// entrySuffix - расширение файлов записей
+const entrySuffix = ".entry"
+
+// path возвращает имя файла для ключа (ключи - url, поэтому хэшируем)
+func (d *diskTier) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+entrySuffix)
+}
+
+// isEntryFile сообщает, что имя файла - имя записи кэша: 64 hex-символа и .entry
+func isEntryFile(name string) bool {
+	hash, ok := strings.CutSuffix(name, entrySuffix)
+	if !ok || len(hash) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+func (d *diskTier) expired(createdAt time.Time) bool {
+	return time.Since(createdAt) > d.ttl
+}
+
+// write атомарно сохраняет запись через временный файл и rename
+func (d *diskTier) write(key string, entry cacheEntry) {
+	data, err := json.Marshal(diskEntry{Key: key, CreatedAt: entry.createdAt, Val: entry.val})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(d.dir, "entry.*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err != nil || closeErr != nil {
+		return
+	}
+	os.Rename(tmp.Name(), d.path(key))
+}
+
+// readFile читает запись с диска; устаревшие записи удаляются.
+// Файл, который не разбирается или без created_at, не наш - его не трогаем
+func (d *diskTier) readFile(path string) (diskEntry, bool) {
+	var entry diskEntry
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil || entry.CreatedAt.IsZero() {
+		return entry, false
+	}
+	if d.expired(entry.CreatedAt) {
+		os.Remove(path)
+		return entry, false
+	}
+	return entry, true
+}
+
+func (d *diskTier) read(key string) ([]byte, bool) {
+	entry, ok := d.readFile(d.path(key))
+	if !ok || entry.Key != key {
+		return nil, false
+	}
+	return entry.Val, true
+}
+
+// remove удаляет файл записи, возвращает false если его не было
+func (d *diskTier) remove(key string) bool {
+	return os.Remove(d.path(key)) == nil
+}
+
+// clear удаляет все файлы записей
+func (d *diskTier) clear() {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if !file.IsDir() && isEntryFile(file.Name()) {
+			os.Remove(filepath.Join(d.dir, file.Name()))
+		}
+	}
+}
+
+// diskSweepInterval - как часто диск проверяется на устаревшие записи.
+// Обход читает весь каталог, поэтому он реже очистки памяти
+const diskSweepInterval = 10 * time.Minute
+
+// sweepInterval возвращает период обхода диска: не чаще очистки памяти
+// и не реже ttl, чтобы короткий ttl соблюдался
+func (d *diskTier) sweepInterval(memoryInterval time.Duration) time.Duration {
+	return max(memoryInterval, min(diskSweepInterval, d.ttl))
+}
+
+// reap удаляет с диска устаревшие записи. Состояние кэша не использует,
+// поэтому вызывается без блокировки MemoryCache
+func (d *diskTier) reap() {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if !file.IsDir() && isEntryFile(file.Name()) {
+			// readFile сам удаляет устаревшую запись
+			d.readFile(filepath.Join(d.dir, file.Name()))
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package pokecache
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// PartySize - сколько покемонов может быть в команде
+const PartySize = 6
+
+// BoxSize - сколько покемонов помещается в один ящик PC
+const BoxSize = 30
+
+// Party возвращает команду по порядку
+func (p *Pokedex) Party() []Pokemonmain {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	party := make([]Pokemonmain, 0, len(p.party))
+	for _, id := range p.party {
+		party = append(party, p.data[id])
+	}
+	return party
+}
+
+// Box возвращает покемонов ящика n, отсортированных по номеру
+func (p *Pokedex) Box(n int) []Pokemonmain {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var box []Pokemonmain
+	for _, pokemon := range p.data {
+		if pokemon.Box == n && n > 0 {
+			box = append(box, pokemon)
+		}
+	}
+	sort.Slice(box, func(i, j int) bool {
+		return box[i].ID < box[j].ID
+	})
+	return box
+}
+
+// Boxes возвращает номера непустых ящиков по возрастанию
+func (p *Pokedex) Boxes() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var boxes []int
+	for _, pokemon := range p.data {
+		if pokemon.Box > 0 && !slices.Contains(boxes, pokemon.Box) {
+			boxes = append(boxes, pokemon.Box)
+		}
+	}
+	sort.Ints(boxes)
+	return boxes
+}
+
+// Deposit кладет покемона из команды в ящик box (0 - в первый со свободным местом)
+// и возвращает номер ящика. Последнего покемона команды положить нельзя
+func (p *Pokedex) Deposit(ref string, box int) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pokemon, ok := p.lookup(ref)
+	if !ok {
+		return 0, fmt.Errorf("you have not caught %s", ref)
+	}
+	if pokemon.Box != 0 {
+		return 0, fmt.Errorf("%s is already in box %d", pokemon.DisplayName(), pokemon.Box)
+	}
+	if len(p.party) == 1 {
+		return 0, errors.New("you cannot deposit your last party pokemon")
+	}
+
+	if box < 0 {
+		return 0, fmt.Errorf("invalid box %d", box)
+	}
+	if box == 0 {
+		box = p.freeBox()
+	} else if p.boxCount(box) >= BoxSize {
+		return 0, fmt.Errorf("box %d is full", box)
+	}
+
+	pokemon.Box = box
+	p.data[pokemon.ID] = pokemon
+	p.party = slices.DeleteFunc(p.party, func(id int) bool { return id == pokemon.ID })
+	return box, nil
+}
+
+// Withdraw забирает покемона из ящика в конец команды
+func (p *Pokedex) Withdraw(ref string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pokemon, ok := p.lookup(ref)
+	if !ok {
+		return fmt.Errorf("you have not caught %s", ref)
+	}
+	if pokemon.Box == 0 {
+		return fmt.Errorf("%s is already in your party", pokemon.DisplayName())
+	}
+	if len(p.party) >= PartySize {
+		return errors.New("your party is full, deposit or swap a pokemon first")
+	}
+
+	pokemon.Box = 0
+	p.data[pokemon.ID] = pokemon
+	p.party = append(p.party, pokemon.ID)
+	return nil
+}
+
+// Swap меняет двух покемонов местами: в команде - позициями,
+// покемона команды и покемона из ящика - местами между командой и ящиком
+func (p *Pokedex) Swap(refA, refB string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	a, ok := p.lookup(refA)
+	if !ok {
+		return fmt.Errorf("you have not caught %s", refA)
+	}
+	b, ok := p.lookup(refB)
+	if !ok {
+		return fmt.Errorf("you have not caught %s", refB)
+	}
+	if a.ID == b.ID {
+		return errors.New("cannot swap a pokemon with itself")
+	}
+
+	slotA := slices.Index(p.party, a.ID)
+	slotB := slices.Index(p.party, b.ID)
+	switch {
+	case slotA >= 0 && slotB >= 0:
+		p.party[slotA], p.party[slotB] = b.ID, a.ID
+	case slotA >= 0:
+		p.party[slotA] = b.ID
+		a.Box, b.Box = b.Box, 0
+	case slotB >= 0:
+		p.party[slotB] = a.ID
+		a.Box, b.Box = 0, a.Box
+	default:
+		a.Box, b.Box = b.Box, a.Box
+	}
+	p.data[a.ID] = a
+	p.data[b.ID] = b
+	return nil
+}
+
+// Reorder ставит покемона команды на позицию slot (с 1), остальные сдвигаются
+func (p *Pokedex) Reorder(ref string, slot int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pokemon, ok := p.lookup(ref)
+	if !ok {
+		return fmt.Errorf("you have not caught %s", ref)
+	}
+	index := slices.Index(p.party, pokemon.ID)
+	if index < 0 {
+		return fmt.Errorf("%s is not in your party", pokemon.DisplayName())
+	}
+	if slot < 1 || slot > len(p.party) {
+		return fmt.Errorf("slot must be between 1 and %d", len(p.party))
+	}
+
+	p.party = slices.Delete(p.party, index, index+1)
+	p.party = slices.Insert(p.party, slot-1, pokemon.ID)
+	return nil
+}
+
+// partyIDs возвращает копию ID команды для сохранения
+func (p *Pokedex) partyIDs() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.party)
+}
+
+// freeBox возвращает первый ящик со свободным местом, вызывается под p.mu
+func (p *Pokedex) freeBox() int {
+	box := 1
+	for p.boxCount(box) >= BoxSize {
+		box++
+	}
+	return box
+}
+
+// boxCount возвращает число покемонов в ящике, вызывается под p.mu
+func (p *Pokedex) boxCount(box int) int {
+	count := 0
+	for _, pokemon := range p.data {
+		if pokemon.Box == box {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,117 @@
+package pokecache
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func names(list []Pokemonmain) []string {
+	result := make([]string, 0, len(list))
+	for _, pokemon := range list {
+		result = append(result, pokemon.Name)
+	}
+	return result
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPartyFillsThenBoxes(t *testing.T) {
+	pokedex := NewPokedex()
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		pokedex.Add(Pokemonmain{Name: name})
+	}
+
+	if got := names(pokedex.Party()); !equal(got, []string{"a", "b", "c", "d", "e", "f"}) {
+		t.Errorf("expected the first six in the party, got %v", got)
+	}
+	if g, _ := pokedex.Get("g"); g.Box != 1 {
+		t.Errorf("expected the seventh in box 1, got box %d", g.Box)
+	}
+	if boxes := pokedex.Boxes(); len(boxes) != 1 || boxes[0] != 1 {
+		t.Errorf("expected [1], got %v", boxes)
+	}
+
+	if err := pokedex.Withdraw("g"); err == nil {
+		t.Error("expected withdraw into a full party to fail")
+	}
+	if err := pokedex.Swap("b", "g"); err != nil {
+		t.Fatalf("Swap failed: %v", err)
+	}
+	if got := names(pokedex.Party()); !equal(got, []string{"a", "g", "c", "d", "e", "f"}) {
+		t.Errorf("expected g to take b's slot, got %v", got)
+	}
+	if got := names(pokedex.Box(1)); !equal(got, []string{"b"}) {
+		t.Errorf("expected b in box 1, got %v", got)
+	}
+
+	if err := pokedex.Reorder("f", 1); err != nil {
+		t.Fatalf("Reorder failed: %v", err)
+	}
+	if got := names(pokedex.Party()); !equal(got, []string{"f", "a", "g", "c", "d", "e"}) {
+		t.Errorf("expected f to lead, got %v", got)
+	}
+	if err := pokedex.Reorder("b", 1); err == nil {
+		t.Error("expected reorder of a boxed pokemon to fail")
+	}
+
+	box, err := pokedex.Deposit("a", 3)
+	if err != nil || box != 3 {
+		t.Fatalf("expected a in box 3, got %d (%v)", box, err)
+	}
+	if err := pokedex.Withdraw("b"); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	if got := names(pokedex.Party()); !equal(got, []string{"f", "g", "c", "d", "e", "b"}) {
+		t.Errorf("expected b at the end of the party, got %v", got)
+	}
+}
+
+func TestDepositKeepsOnePartyPokemon(t *testing.T) {
+	pokedex := NewPokedex()
+	pokedex.Add(Pokemonmain{Name: "pikachu"})
+
+	if _, err := pokedex.Deposit("pikachu", 0); err == nil {
+		t.Error("expected deposit of the last party pokemon to fail")
+	}
+
+	pokedex.Add(Pokemonmain{Name: "eevee"})
+	pokedex.Remove("pikachu")
+	if got := names(pokedex.Party()); !equal(got, []string{"eevee"}) {
+		t.Errorf("expected removed pokemon to leave the party, got %v", got)
+	}
+}
+
+func TestPartySaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+
+	pokedex := NewPokedex()
+	for _, name := range []string{"a", "b", "c"} {
+		pokedex.Add(Pokemonmain{Name: name})
+	}
+	pokedex.Reorder("c", 1)
+	pokedex.Deposit("b", 2)
+	if err := pokedex.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadPokedex(path)
+	if err != nil {
+		t.Fatalf("LoadPokedex failed: %v", err)
+	}
+	if got := names(loaded.Party()); !equal(got, []string{"c", "a"}) {
+		t.Errorf("expected party [c a] after load, got %v", got)
+	}
+	if got := names(loaded.Box(2)); !equal(got, []string{"b"}) {
+		t.Errorf("expected b in box 2 after load, got %v", got)
+	}
+}
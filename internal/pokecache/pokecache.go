@@ -1,7 +1,9 @@
 package pokecache
 
 import (
+	"container/list"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 )
@@ -9,107 +11,292 @@ import (
 type cacheEntry struct {
 	createdAt time.Time
 	val       []byte
+	// elem - позиция ключа в списке LRU
+	elem *list.Element
 }
 
-type Cache struct {
+// Cache - общий интерфейс кэша ответов.
+// Его реализуют MemoryCache и тестовые подмены (например MockCache)
+type Cache interface {
+	Add(key string, val []byte)
+	Get(key string) ([]byte, bool)
+	Stop()
+}
+
+// Stats - счетчики работы кэша
+type Stats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+	// Reaped - записи, удаленные по истечении interval
+	Reaped  int
+	Entries int
+	Bytes   int
+}
+
+// KeyInfo описывает одну запись кэша в памяти
+type KeyInfo struct {
+	Key  string
+	Age  time.Duration
+	Size int
+}
+
+// Inspector - кэш, который умеет показывать и чистить свое содержимое
+type Inspector interface {
+	Stats() Stats
+	Keys() []KeyInfo
+	Delete(key string) bool
+	Clear()
+}
+
+// MemoryCache - кэш в памяти, записи удаляются спустя interval
+type MemoryCache struct {
 	mu       *sync.Mutex
 	data     map[string]cacheEntry
 	interval time.Duration
 	stop     chan struct{}
-}
 
-type Pokemonmain struct {
-	Name      string
-	CreatedAt time.Time
-}
+	// disk - необязательный дисковый уровень под картой в памяти
+	disk *diskTier
+
+	// lru - ключи от недавно использованных к давно использованным
+	lru        *list.List
+	bytes      int
+	maxEntries int
+	maxBytes   int
 
-type Pokedex struct {
-	mu   *sync.Mutex
-	data map[string]Pokemonmain
+	stats Stats
 }
 
-func (p *Pokedex) Add(pokemon string) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	p.data[pokemon] = Pokemonmain{
-		Name:      pokemon,
-		CreatedAt: time.Now(),
+// Option настраивает MemoryCache при создании
+type Option func(*MemoryCache)
+
+// WithDisk включает дисковый уровень: записи сохраняются в dir
+// и живут там ttl, переживая перезапуск программы
+func WithDisk(dir string, ttl time.Duration) Option {
+	return func(c *MemoryCache) {
+		c.disk = &diskTier{dir: dir, ttl: ttl}
 	}
-	// fmt.Println("ВСЕГО ПОКЕМОНОВ В POKEDEX", len(p.data))
 }
 
-func NewPokedex() *Pokedex {
+// WithMaxEntries ограничивает число записей в памяти (0 - без ограничения).
+// При превышении вытесняются давно не использованные записи
+func WithMaxEntries(n int) Option {
+	return func(c *MemoryCache) {
+		c.maxEntries = n
+	}
+}
 
-	pokedex := &Pokedex{
-		mu:   &sync.Mutex{},
-		data: make(map[string]Pokemonmain),
+// WithMaxBytes ограничивает суммарный размер значений в памяти (0 - без ограничения)
+func WithMaxBytes(n int) Option {
+	return func(c *MemoryCache) {
+		c.maxBytes = n
 	}
-	return pokedex
 }
 
-func NewCache(interval time.Duration) *Cache {
+func NewCache(interval time.Duration, opts ...Option) *MemoryCache {
 
-	cache := &Cache{
+	cache := &MemoryCache{
 		mu:       &sync.Mutex{},
 		data:     make(map[string]cacheEntry),
 		interval: interval,
 		stop:     make(chan struct{}),
+		lru:      list.New(),
+	}
+	for _, opt := range opts {
+		opt(cache)
 	}
+
 	go cache.reapLoop()
 
 	return cache
 }
 
-func (c *Cache) reap() {
+// set кладет значение в память и вытесняет лишнее. Вызывается под mu
+func (c *MemoryCache) set(key string, val []byte) cacheEntry {
+	c.remove(key)
+
+	entry := cacheEntry{
+		createdAt: time.Now(),
+		val:       val,
+		elem:      c.lru.PushFront(key),
+	}
+	c.data[key] = entry
+	c.bytes += len(val)
+
+	c.evict()
+	return entry
+}
+
+// remove удаляет запись из памяти. Вызывается под mu
+func (c *MemoryCache) remove(key string) bool {
+	entry, ok := c.data[key]
+	if !ok {
+		return false
+	}
+	c.lru.Remove(entry.elem)
+	c.bytes -= len(entry.val)
+	delete(c.data, key)
+	return true
+}
+
+// evict вытесняет давно не использованные записи, пока не уложимся в лимиты
+func (c *MemoryCache) evict() {
+	for c.lru.Len() > 0 &&
+		(c.maxEntries > 0 && len(c.data) > c.maxEntries || c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		oldest := c.lru.Back()
+		c.remove(oldest.Value.(string))
+		c.stats.Evictions++
+	}
+}
+
+// Evictions возвращает, сколько записей вытеснено из-за лимитов размера
+func (c *MemoryCache) Evictions() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats.Evictions
+}
+
+// Stats возвращает текущие счетчики кэша
+func (c *MemoryCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = len(c.data)
+	stats.Bytes = c.bytes
+	return stats
+}
+
+// Keys возвращает записи в памяти, отсортированные по ключу
+func (c *MemoryCache) Keys() []KeyInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]KeyInfo, 0, len(c.data))
+	for key, entry := range c.data {
+		keys = append(keys, KeyInfo{
+			Key:  key,
+			Age:  time.Since(entry.createdAt),
+			Size: len(entry.val),
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Key < keys[j].Key
+	})
+	return keys
+}
+
+// Delete удаляет запись из памяти и с диска
+func (c *MemoryCache) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	removed := c.remove(key)
+	if c.disk != nil && c.disk.remove(key) {
+		removed = true
+	}
+	return removed
+}
+
+// Clear удаляет все записи из памяти и с диска, счетчики сохраняются
+func (c *MemoryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.data {
+		c.remove(key)
+	}
+	if c.disk != nil {
+		c.disk.clear()
+	}
+}
+
+func (c *MemoryCache) reap() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	cutoff := time.Now().Add(-c.interval)
 	for key, entry := range c.data {
 		if entry.createdAt.Before(cutoff) {
-			delete(c.data, key)
+			c.remove(key)
+			c.stats.Reaped++
 		}
 
 	}
 }
 
-func (c *Cache) reapLoop() {
+// reapLoop чистит память каждые interval, а диск - реже и без блокировки mu,
+// чтобы обход каталога не задерживал Get и Add.
+// Записи с диска в память поднимаются лениво, при промахе в Get
+func (c *MemoryCache) reapLoop() {
 	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	var sweep <-chan time.Time
+	if c.disk != nil {
+		c.disk.reap()
+		sweepTicker := time.NewTicker(c.disk.sweepInterval(c.interval))
+		defer sweepTicker.Stop()
+		sweep = sweepTicker.C
+	}
 
 	for {
 		select {
 		case <-ticker.C:
 			c.reap()
+		case <-sweep:
+			c.disk.reap()
 		case <-c.stop:
 			return
 		}
 	}
 }
 
-func (c *Cache) Stop() {
+func (c *MemoryCache) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	for key := range c.data {
-		delete(c.data, key)
+		c.remove(key)
 	}
 	fmt.Println("...ALL CACHED DATA DELETED...")
 	close(c.stop)
 }
 
 // Методы для работы с кэшем
-func (c *Cache) Add(key string, val []byte) {
+func (c *MemoryCache) Add(key string, val []byte) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.data[key] = cacheEntry{
-		createdAt: time.Now(),
-		val:       val,
+	entry := c.set(key, val)
+
+	if c.disk != nil {
+		c.disk.write(key, entry)
 	}
 }
 
-func (c *Cache) Get(key string) ([]byte, bool) {
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	entry, exists := c.data[key]
-	if !exists {
+	// Запись могла устареть, а reapLoop еще не успел ее удалить
+	if exists && time.Since(entry.createdAt) > c.interval {
+		c.remove(key)
+		c.stats.Reaped++
+		exists = false
+	}
+	if exists {
+		c.lru.MoveToFront(entry.elem)
+		c.stats.Hits++
+		return entry.val, true
+	}
+
+	// Промах в памяти - пробуем диск
+	if c.disk == nil {
+		c.stats.Misses++
+		return nil, false
+	}
+	val, ok := c.disk.read(key)
+	if !ok {
+		c.stats.Misses++
 		return nil, false
 	}
-	return entry.val, true
+	c.set(key, val)
+	c.stats.Hits++
+	return val, true
 }
@@ -0,0 +1,246 @@
+package pokecache
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stat - базовое значение одной характеристики покемона (hp, attack, ...)
+type Stat struct {
+	Name     string `json:"name"`
+	BaseStat int    `json:"base_stat"`
+}
+
+// Pokemonmain хранит одного пойманного покемона (экземпляр вида), нужные для inspect данные
+type Pokemonmain struct {
+	// ID - уникальный номер экземпляра в Pokedex, назначается в Add
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Species - вид покемона, может отличаться от Name у форм (deoxys-normal)
+	Species  string `json:"species,omitempty"`
+	Nickname string `json:"nickname,omitempty"`
+	// Level - уровень; в старых сохранениях 0
+	Level int `json:"level,omitempty"`
+	// Experience - накопленный опыт, растет по кривой GrowthRate вида
+	Experience int    `json:"experience,omitempty"`
+	GrowthRate string `json:"growth_rate,omitempty"`
+	// Box - номер ящика PC (с 1), 0 - покемон в команде, см. Party
+	Box int `json:"box,omitempty"`
+	// Area - локация, в которой покемон пойман
+	Area           string    `json:"area,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	Height         int       `json:"height"`
+	Weight         int       `json:"weight"`
+	BaseExperience int       `json:"base_experience"`
+	Stats          []Stat    `json:"stats"`
+	// LevelStats - характеристики на текущем уровне, пересчитываются при его смене
+	LevelStats []Stat   `json:"level_stats,omitempty"`
+	Types      []string `json:"types"`
+	Moves      []string `json:"moves,omitempty"`
+}
+
+// DisplayName возвращает прозвище покемона, а если его нет - имя вида
+func (p Pokemonmain) DisplayName() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.Name
+}
+
+// SpeciesCount - сколько экземпляров вида есть в Pokedex
+type SpeciesCount struct {
+	Name  string
+	Count int
+}
+
+type Pokedex struct {
+	mu     *sync.Mutex
+	data   map[int]Pokemonmain
+	nextID int
+	// seen - виды, которые тренер встречал (пойманные тоже считаются встреченными)
+	seen map[string]bool
+	// caught - виды, которые тренер когда-либо ловил, даже если экземпляров
+	// уже нет (после эволюции или Remove)
+	caught map[string]bool
+	// party - ID покемонов команды по порядку, остальные лежат в ящиках PC
+	party []int
+}
+
+func NewPokedex() *Pokedex {
+
+	pokedex := &Pokedex{
+		mu:     &sync.Mutex{},
+		data:   make(map[int]Pokemonmain),
+		nextID: 1,
+		seen:   make(map[string]bool),
+		caught: make(map[string]bool),
+	}
+	return pokedex
+}
+
+// Add сохраняет покемона как новый экземпляр и возвращает его с назначенным ID.
+// Экземпляр с уже заданным ID (например, из файла) сохраняется под ним.
+// Новый покемон попадает в команду, а если в ней нет места - в ящик PC
+func (p *Pokedex) Add(pokemon Pokemonmain) Pokemonmain {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pokemon.CreatedAt.IsZero() {
+		pokemon.CreatedAt = time.Now()
+	}
+	if pokemon.ID == 0 {
+		pokemon.ID = p.nextID
+	}
+	p.nextID = max(p.nextID, pokemon.ID+1)
+	if pokemon.Box == 0 && !slices.Contains(p.party, pokemon.ID) {
+		if len(p.party) < PartySize {
+			p.party = append(p.party, pokemon.ID)
+		} else {
+			pokemon.Box = p.freeBox()
+		}
+	}
+	p.data[pokemon.ID] = pokemon
+	p.seen[pokemon.Name] = true
+	p.caught[pokemon.Name] = true
+	return pokemon
+}
+
+// MarkSeen отмечает вид как встреченный
+func (p *Pokedex) MarkSeen(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.seen[name] = true
+}
+
+// Seen сообщает, встречал ли тренер вид
+func (p *Pokedex) Seen(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.seen[name]
+}
+
+// SeenList возвращает встреченные виды, отсортированные по имени
+func (p *Pokedex) SeenList() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	list := make([]string, 0, len(p.seen))
+	for name := range p.seen {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// CaughtList возвращает виды, которые тренер когда-либо ловил, отсортированные по имени
+func (p *Pokedex) CaughtList() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	list := make([]string, 0, len(p.caught))
+	for name := range p.caught {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Update заменяет сохраненный экземпляр с тем же ID (например, после эволюции),
+// возвращает false если его нет. Место покемона (команда или ящик) не меняется
+func (p *Pokedex) Update(pokemon Pokemonmain) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stored, ok := p.data[pokemon.ID]
+	if !ok {
+		return false
+	}
+	pokemon.Box = stored.Box
+	p.data[pokemon.ID] = pokemon
+	p.seen[pokemon.Name] = true
+	p.caught[pokemon.Name] = true
+	return true
+}
+
+// Get возвращает пойманного покемона по ссылке: номеру (#3 или 3), прозвищу
+// или имени вида. Для имени вида возвращается пойманный первым экземпляр
+func (p *Pokedex) Get(ref string) (Pokemonmain, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lookup(ref)
+}
+
+// lookup ищет экземпляр по ссылке, вызывается под p.mu
+func (p *Pokedex) lookup(ref string) (Pokemonmain, bool) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		pokemon, ok := p.data[id]
+		return pokemon, ok
+	}
+
+	var found Pokemonmain
+	byNickname := false
+	for _, pokemon := range p.data {
+		switch {
+		case pokemon.Nickname == ref:
+			// Прозвище важнее имени вида
+			if !byNickname || pokemon.ID < found.ID {
+				found, byNickname = pokemon, true
+			}
+		case pokemon.Name == ref && !byNickname:
+			if found.ID == 0 || pokemon.ID < found.ID {
+				found = pokemon
+			}
+		}
+	}
+	return found, found.ID != 0
+}
+
+// List возвращает копию всех пойманных покемонов, отсортированную по имени и номеру
+func (p *Pokedex) List() []Pokemonmain {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	list := make([]Pokemonmain, 0, len(p.data))
+	for _, pokemon := range p.data {
+		list = append(list, pokemon)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// Species возвращает число экземпляров каждого вида, отсортированное по имени
+func (p *Pokedex) Species() []SpeciesCount {
+	var species []SpeciesCount
+	for _, pokemon := range p.List() {
+		if n := len(species); n > 0 && species[n-1].Name == pokemon.Name {
+			species[n-1].Count++
+			continue
+		}
+		species = append(species, SpeciesCount{Name: pokemon.Name, Count: 1})
+	}
+	return species
+}
+
+// Len возвращает количество пойманных покемонов (экземпляров)
+func (p *Pokedex) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.data)
+}
+
+// Remove удаляет один экземпляр по ссылке (см. Get), возвращает false если его там не было
+func (p *Pokedex) Remove(ref string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pokemon, ok := p.lookup(ref)
+	if !ok {
+		return false
+	}
+	delete(p.data, pokemon.ID)
+	p.party = slices.DeleteFunc(p.party, func(id int) bool { return id == pokemon.ID })
+	return true
+}
@@ -0,0 +1,110 @@
+package pokecache
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+)
+
+// pokedexFile - формат файла сохранения
+type pokedexFile struct {
+	Pokemon []Pokemonmain `json:"pokemon"`
+	// Seen - встреченные виды, в старых сохранениях отсутствует
+	Seen []string `json:"seen,omitempty"`
+	// Caught - пойманные когда-либо виды; в старых сохранениях берутся из экземпляров
+	Caught []string `json:"caught,omitempty"`
+	// Party - ID покемонов команды по порядку
+	Party []int `json:"party,omitempty"`
+}
+
+// LoadPokedex читает Pokedex из файла.
+// Если файла еще нет, возвращается пустой Pokedex.
+func LoadPokedex(path string) (*Pokedex, error) {
+	pokedex := NewPokedex()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return pokedex, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file pokedexFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	// Команда из файла; покемоны без ящика и не из команды (старые сохранения)
+	// добавляются в нее при Add, пока есть место, остальные - в ящики
+	pokedex.party = file.Party
+	sort.SliceStable(file.Pokemon, func(i, j int) bool {
+		return file.Pokemon[i].ID < file.Pokemon[j].ID
+	})
+
+	// Сначала экземпляры с номерами, чтобы старые сохранения без ID
+	// (по одному покемону на вид) получили следующие свободные номера
+	for _, pokemon := range file.Pokemon {
+		if pokemon.ID != 0 {
+			pokedex.Add(pokemon)
+		}
+	}
+	for _, pokemon := range file.Pokemon {
+		if pokemon.ID == 0 {
+			pokedex.Add(pokemon)
+		}
+	}
+	// В команде остаются только сохраненные покемоны не из ящиков
+	pokedex.party = slices.DeleteFunc(pokedex.party, func(id int) bool {
+		pokemon, ok := pokedex.data[id]
+		return !ok || pokemon.Box != 0
+	})
+	for _, name := range file.Seen {
+		pokedex.seen[name] = true
+	}
+	for _, name := range file.Caught {
+		pokedex.caught[name] = true
+		pokedex.seen[name] = true
+	}
+
+	return pokedex, nil
+}
+
+// Save атомарно записывает Pokedex в файл:
+// данные пишутся во временный файл рядом и затем переименовываются,
+// поэтому сбой посреди записи не портит предыдущее сохранение
+func (p *Pokedex) Save(path string) error {
+	data, err := json.MarshalIndent(pokedexFile{Pokemon: p.List(), Seen: p.SeenList(), Caught: p.CaughtList(), Party: p.partyIDs()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// После успешного Rename удалять уже нечего
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,231 @@
+package pokecache
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPokedexAddGet(t *testing.T) {
+	pokedex := NewPokedex()
+	pokedex.Add(Pokemonmain{Name: "pikachu", BaseExperience: 112})
+
+	pokemon, ok := pokedex.Get("pikachu")
+	if !ok {
+		t.Fatalf("expected to find pikachu")
+	}
+	if pokemon.BaseExperience != 112 {
+		t.Errorf("expected base experience 112, got %d", pokemon.BaseExperience)
+	}
+	if pokemon.CreatedAt.IsZero() {
+		t.Errorf("expected CreatedAt to be set")
+	}
+
+	if _, ok := pokedex.Get("mewtwo"); ok {
+		t.Errorf("expected not to find mewtwo")
+	}
+}
+
+func TestPokedexListLenRemove(t *testing.T) {
+	pokedex := NewPokedex()
+	for _, name := range []string{"pidgey", "bulbasaur", "rattata"} {
+		pokedex.Add(Pokemonmain{Name: name})
+	}
+
+	if pokedex.Len() != 3 {
+		t.Errorf("expected 3 pokemons, got %d", pokedex.Len())
+	}
+
+	list := pokedex.List()
+	expected := []string{"bulbasaur", "pidgey", "rattata"}
+	for i, name := range expected {
+		if list[i].Name != name {
+			t.Errorf("expected %s at position %d, got %s", name, i, list[i].Name)
+		}
+	}
+
+	if !pokedex.Remove("pidgey") {
+		t.Errorf("expected Remove to report pidgey as removed")
+	}
+	if pokedex.Remove("pidgey") {
+		t.Errorf("expected second Remove to return false")
+	}
+	if pokedex.Len() != 2 {
+		t.Errorf("expected 2 pokemons after Remove, got %d", pokedex.Len())
+	}
+}
+
+func TestPokedexSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "pokedex.json")
+
+	pokedex := NewPokedex()
+	pokedex.Add(Pokemonmain{
+		Name:  "pikachu",
+		Stats: []Stat{{Name: "speed", BaseStat: 90}},
+		Types: []string{"electric"},
+	})
+	if err := pokedex.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadPokedex(path)
+	if err != nil {
+		t.Fatalf("LoadPokedex failed: %v", err)
+	}
+	pokemon, ok := loaded.Get("pikachu")
+	if !ok {
+		t.Fatalf("expected to find pikachu after load")
+	}
+	if len(pokemon.Stats) != 1 || pokemon.Stats[0].BaseStat != 90 {
+		t.Errorf("expected stats to survive save/load, got %v", pokemon.Stats)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the save file in directory, got %d entries", len(entries))
+	}
+}
+
+func TestLoadPokedexMissingFile(t *testing.T) {
+	pokedex, err := LoadPokedex(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("expected no error for missing file, got %v", err)
+	}
+	if pokedex.Len() != 0 {
+		t.Errorf("expected empty pokedex, got %d entries", pokedex.Len())
+	}
+}
+
+func TestLoadPokedexCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPokedex(path); err == nil {
+		t.Error("expected error for corrupted file")
+	}
+}
+
+func TestPokedexKeepsEveryCatch(t *testing.T) {
+	pokedex := NewPokedex()
+	first := pokedex.Add(Pokemonmain{Name: "pikachu", Area: "viridian-forest-area"})
+	second := pokedex.Add(Pokemonmain{Name: "pikachu", Nickname: "sparky"})
+	pokedex.Add(Pokemonmain{Name: "eevee"})
+
+	if first.ID == second.ID {
+		t.Fatalf("expected unique IDs, got %d twice", first.ID)
+	}
+	if pokedex.Len() != 3 {
+		t.Errorf("expected 3 pokemons, got %d", pokedex.Len())
+	}
+
+	cases := []struct {
+		ref      string
+		expected int
+	}{
+		{ref: "pikachu", expected: first.ID},
+		{ref: "sparky", expected: second.ID},
+		{ref: "#2", expected: second.ID},
+		{ref: "1", expected: first.ID},
+	}
+	for _, c := range cases {
+		pokemon, ok := pokedex.Get(c.ref)
+		if !ok || pokemon.ID != c.expected {
+			t.Errorf("Get(%q): expected #%d, got #%d (%v)", c.ref, c.expected, pokemon.ID, ok)
+		}
+	}
+
+	species := pokedex.Species()
+	if len(species) != 2 || species[1].Name != "pikachu" || species[1].Count != 2 {
+		t.Errorf("expected [eevee x1 pikachu x2], got %v", species)
+	}
+
+	if !pokedex.Remove("sparky") {
+		t.Fatal("expected Remove by nickname to succeed")
+	}
+	if pokemon, ok := pokedex.Get("pikachu"); !ok || pokemon.ID != first.ID {
+		t.Errorf("expected the first pikachu to stay, got %v", pokemon)
+	}
+}
+
+func TestLoadPokedexWithoutIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	data := `{"pokemon":[{"name":"pidgey"},{"id":1,"name":"eevee"},{"name":"rattata"}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pokedex, err := LoadPokedex(path)
+	if err != nil {
+		t.Fatalf("LoadPokedex failed: %v", err)
+	}
+	if pokedex.Len() != 3 {
+		t.Fatalf("expected 3 pokemons, got %d", pokedex.Len())
+	}
+	if eevee, _ := pokedex.Get("eevee"); eevee.ID != 1 {
+		t.Errorf("expected eevee to keep #1, got #%d", eevee.ID)
+	}
+	if pidgey, _ := pokedex.Get("pidgey"); pidgey.ID < 2 {
+		t.Errorf("expected pidgey to get a free number, got #%d", pidgey.ID)
+	}
+}
+
+func TestPokedexSeen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+
+	pokedex := NewPokedex()
+	pokedex.MarkSeen("zubat")
+	pokedex.Add(Pokemonmain{Name: "geodude"})
+
+	if !pokedex.Seen("zubat") || !pokedex.Seen("geodude") {
+		t.Error("expected both seen and caught species to be seen")
+	}
+	if pokedex.Seen("onix") {
+		t.Error("expected onix not to be seen")
+	}
+
+	if err := pokedex.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadPokedex(path)
+	if err != nil {
+		t.Fatalf("LoadPokedex failed: %v", err)
+	}
+	if seen := loaded.SeenList(); len(seen) != 2 || seen[0] != "geodude" || seen[1] != "zubat" {
+		t.Errorf("expected [geodude zubat] after load, got %v", seen)
+	}
+}
+
+func TestPokedexCaughtSurvivesEvolveAndRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+
+	pokedex := NewPokedex()
+	pokedex.MarkSeen("zubat")
+	charmander := pokedex.Add(Pokemonmain{Name: "charmander"})
+	pokedex.Add(Pokemonmain{Name: "geodude"})
+
+	// Эволюция и отпущенный покемон не уменьшают число пойманных видов
+	charmander.Name = "charmeleon"
+	pokedex.Update(charmander)
+	pokedex.Remove("geodude")
+
+	expected := []string{"charmander", "charmeleon", "geodude"}
+	if caught := pokedex.CaughtList(); !slices.Equal(caught, expected) {
+		t.Errorf("expected %v, got %v", expected, caught)
+	}
+
+	if err := pokedex.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadPokedex(path)
+	if err != nil {
+		t.Fatalf("LoadPokedex failed: %v", err)
+	}
+	if caught := loaded.CaughtList(); !slices.Equal(caught, expected) {
+		t.Errorf("expected %v after load, got %v", expected, caught)
+	}
+}
@@ -0,0 +1,347 @@
+package readline
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrInterrupt возвращается из ReadLine, когда пользователь нажал Ctrl-C
+var ErrInterrupt = errors.New("interrupted")
+
+// maxHistory - сколько последних строк хранится в истории
+const maxHistory = 1000
+
+// Completer возвращает варианты для последнего слова строки line
+// (line - текст до курсора). Editor сам отбирает варианты по префиксу слова
+type Completer func(line string) []string
+
+// Editor читает строки из терминала с редактированием, историей и автодополнением.
+// Если ввод не терминал, строки читаются как есть
+type Editor struct {
+	in     *os.File
+	out    io.Writer
+	reader *bufio.Reader
+
+	history     []string
+	historyPath string
+
+	Complete Completer
+}
+
+// New создает Editor. historyPath - файл истории (пустой - история только в памяти)
+func New(in *os.File, out io.Writer, historyPath string) *Editor {
+	e := &Editor{
+		in:          in,
+		out:         out,
+		reader:      bufio.NewReader(in),
+		historyPath: historyPath,
+	}
+	e.loadHistory()
+	return e
+}
+
+// ReadLine печатает prompt и возвращает введенную строку.
+// На Ctrl-D в пустой строке возвращает io.EOF, на Ctrl-C - ErrInterrupt
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	fd := int(e.in.Fd())
+	if !isTerminal(fd) {
+		return e.readPlain(prompt)
+	}
+	state, err := makeRaw(fd)
+	if err != nil {
+		return e.readPlain(prompt)
+	}
+
+	line, err := e.edit(prompt)
+	restore(fd, state)
+	fmt.Fprint(e.out, "\n")
+
+	if err == nil {
+		e.addHistory(line)
+	}
+	return line, err
+}
+
+// History возвращает копию истории, от старых строк к новым
+func (e *Editor) History() []string {
+	return append([]string(nil), e.history...)
+}
+
+func (e *Editor) readPlain(prompt string) (string, error) {
+	fmt.Fprint(e.out, prompt)
+	line, err := e.reader.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// lineState - редактируемая строка и положение курсора
+type lineState struct {
+	prompt string
+	buf    []rune
+	pos    int
+}
+
+// edit обрабатывает нажатия клавиш в raw режиме до Enter
+func (e *Editor) edit(prompt string) (string, error) {
+	s := &lineState{prompt: prompt}
+	// histIdx == len(e.history) - редактируется новая строка, ее текст в draft
+	histIdx := len(e.history)
+	var draft []rune
+
+	e.refresh(s)
+	for {
+		r, _, err := e.reader.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			return string(s.buf), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(e.out, "^C")
+			return "", ErrInterrupt
+		case 4: // Ctrl-D
+			if len(s.buf) == 0 {
+				return "", io.EOF
+			}
+			s.deleteAt(s.pos)
+		case 127, 8: // Backspace
+			if s.pos > 0 {
+				s.pos--
+				s.deleteAt(s.pos)
+			}
+		case 1: // Ctrl-A
+			s.pos = 0
+		case 5: // Ctrl-E
+			s.pos = len(s.buf)
+		case 2: // Ctrl-B
+			s.left()
+		case 6: // Ctrl-F
+			s.right()
+		case 11: // Ctrl-K
+			s.buf = s.buf[:s.pos]
+		case 21: // Ctrl-U
+			s.buf = append([]rune(nil), s.buf[s.pos:]...)
+			s.pos = 0
+		case 23: // Ctrl-W
+			s.deleteWord()
+		case 16: // Ctrl-P
+			histIdx, draft = e.historyMove(s, histIdx, -1, draft)
+		case 14: // Ctrl-N
+			histIdx, draft = e.historyMove(s, histIdx, 1, draft)
+		case '\t':
+			e.complete(s)
+		case 27: // ESC - последовательность клавиш со стрелками и т.п.
+			switch e.readEscape() {
+			case "A":
+				histIdx, draft = e.historyMove(s, histIdx, -1, draft)
+			case "B":
+				histIdx, draft = e.historyMove(s, histIdx, 1, draft)
+			case "C":
+				s.right()
+			case "D":
+				s.left()
+			case "H", "1~", "7~":
+				s.pos = 0
+			case "F", "4~", "8~":
+				s.pos = len(s.buf)
+			case "3~":
+				s.deleteAt(s.pos)
+			}
+		default:
+			if r >= 32 {
+				s.insert(r)
+			}
+		}
+
+		e.refresh(s)
+	}
+}
+
+// readEscape читает остаток ESC последовательности: "A" для ESC [ A, "3~" для ESC [ 3 ~
+func (e *Editor) readEscape() string {
+	r, _, err := e.reader.ReadRune()
+	if err != nil || (r != '[' && r != 'O') {
+		return ""
+	}
+	var seq []rune
+	for {
+		r, _, err := e.reader.ReadRune()
+		if err != nil {
+			return ""
+		}
+		seq = append(seq, r)
+		if r < '0' || r > '9' {
+			return string(seq)
+		}
+	}
+}
+
+func (e *Editor) refresh(s *lineState) {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", s.prompt, string(s.buf))
+	if back := len(s.buf) - s.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
+
+// historyMove листает историю на delta строк, сохраняя набранную строку в draft
+func (e *Editor) historyMove(s *lineState, idx, delta int, draft []rune) (int, []rune) {
+	next := idx + delta
+	if next < 0 || next > len(e.history) {
+		return idx, draft
+	}
+	if idx == len(e.history) {
+		draft = append([]rune(nil), s.buf...)
+	}
+	if next == len(e.history) {
+		s.buf = append([]rune(nil), draft...)
+	} else {
+		s.buf = []rune(e.history[next])
+	}
+	s.pos = len(s.buf)
+	return next, draft
+}
+
+// complete дополняет слово под курсором
+func (e *Editor) complete(s *lineState) {
+	if e.Complete == nil {
+		return
+	}
+	before := string(s.buf[:s.pos])
+	word := before[strings.LastIndex(before, " ")+1:]
+
+	var matches []string
+	seen := make(map[string]bool)
+	for _, candidate := range e.Complete(before) {
+		if strings.HasPrefix(candidate, word) && !seen[candidate] {
+			seen[candidate] = true
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+
+	switch len(matches) {
+	case 0:
+		fmt.Fprint(e.out, "\a")
+	case 1:
+		s.replaceWord(len([]rune(word)), matches[0]+" ")
+	default:
+		prefix := commonPrefix(matches)
+		if len(prefix) > len(word) {
+			s.replaceWord(len([]rune(word)), prefix)
+			return
+		}
+		fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(matches, "  "))
+	}
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+func (s *lineState) insert(r rune) {
+	s.buf = append(s.buf, 0)
+	copy(s.buf[s.pos+1:], s.buf[s.pos:])
+	s.buf[s.pos] = r
+	s.pos++
+}
+
+func (s *lineState) deleteAt(i int) {
+	if i < len(s.buf) {
+		s.buf = append(s.buf[:i], s.buf[i+1:]...)
+	}
+}
+
+func (s *lineState) left() {
+	if s.pos > 0 {
+		s.pos--
+	}
+}
+
+func (s *lineState) right() {
+	if s.pos < len(s.buf) {
+		s.pos++
+	}
+}
+
+// deleteWord удаляет слово перед курсором (Ctrl-W)
+func (s *lineState) deleteWord() {
+	start := s.pos
+	for start > 0 && s.buf[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && s.buf[start-1] != ' ' {
+		start--
+	}
+	s.buf = append(s.buf[:start], s.buf[s.pos:]...)
+	s.pos = start
+}
+
+// replaceWord заменяет n символов перед курсором на text
+func (s *lineState) replaceWord(n int, text string) {
+	tail := append([]rune(text), s.buf[s.pos:]...)
+	s.buf = append(s.buf[:s.pos-n], tail...)
+	s.pos = s.pos - n + len([]rune(text))
+}
+
+func (e *Editor) loadHistory() {
+	if e.historyPath == "" {
+		return
+	}
+	data, err := os.ReadFile(e.historyPath)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			e.history = append(e.history, line)
+		}
+	}
+	if len(e.history) > maxHistory {
+		e.history = e.history[len(e.history)-maxHistory:]
+		os.WriteFile(e.historyPath, []byte(strings.Join(e.history, "\n")+"\n"), 0o600)
+	}
+}
+
+// addHistory добавляет строку в историю и дописывает ее в файл
+func (e *Editor) addHistory(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	if n := len(e.history); n > 0 && e.history[n-1] == line {
+		return
+	}
+	e.history = append(e.history, line)
+	if len(e.history) > maxHistory {
+		e.history = e.history[1:]
+	}
+
+	if e.historyPath == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(e.historyPath), 0o755); err != nil {
+		return
+	}
+	file, err := os.OpenFile(e.historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	fmt.Fprintln(file, line)
+}
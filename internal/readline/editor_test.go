@@ -0,0 +1,107 @@
+package readline
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestEditor создает Editor, читающий нажатия клавиш из строки keys
+func newTestEditor(keys string, history ...string) *Editor {
+	return &Editor{
+		out:     &bytes.Buffer{},
+		reader:  bufio.NewReader(strings.NewReader(keys)),
+		history: history,
+	}
+}
+
+func TestEdit(t *testing.T) {
+	cases := []struct {
+		name     string
+		keys     string
+		history  []string
+		expected string
+	}{
+		{name: "plain input", keys: "map\r", expected: "map"},
+		{name: "backspace", keys: "mapx\x7f\r", expected: "map"},
+		{name: "cursor movement", keys: "mp\x1b[Da\r", expected: "map"},
+		{name: "home and end", keys: "xplore\x01e\x05 area\r", expected: "explore area"},
+		{name: "delete key", keys: "mapp\x1b[D\x1b[3~\r", expected: "map"},
+		{name: "ctrl-w deletes a word", keys: "catch pikachu\x17mew\r", expected: "catch mew"},
+		{name: "history up", keys: "\x1b[A\x1b[A\r", history: []string{"map", "help"}, expected: "map"},
+		{name: "history down restores draft", keys: "ex\x1b[A\x1b[B\r", history: []string{"map"}, expected: "ex"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := newTestEditor(c.keys, c.history...)
+			line, err := e.edit("> ")
+			if err != nil {
+				t.Fatalf("edit returned error: %v", err)
+			}
+			if line != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, line)
+			}
+		})
+	}
+}
+
+func TestEditControlKeys(t *testing.T) {
+	if _, err := newTestEditor("\x04").edit("> "); err != io.EOF {
+		t.Errorf("expected io.EOF on Ctrl-D, got %v", err)
+	}
+	if _, err := newTestEditor("ma\x03").edit("> "); err != ErrInterrupt {
+		t.Errorf("expected ErrInterrupt on Ctrl-C, got %v", err)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	complete := func(line string) []string {
+		if !strings.Contains(line, " ") {
+			return []string{"map", "mapb", "explore", "exit"}
+		}
+		return []string{"pikachu", "pidgey", "pidgeotto"}
+	}
+
+	cases := []struct {
+		keys     string
+		expected string
+	}{
+		{keys: "exp\t\r", expected: "explore "},
+		{keys: "catch pid\t\r", expected: "catch pidge"},
+		{keys: "catch pik\t\r", expected: "catch pikachu "},
+		{keys: "zz\t\r", expected: "zz"},
+	}
+
+	for _, c := range cases {
+		e := newTestEditor(c.keys)
+		e.Complete = complete
+		line, err := e.edit("> ")
+		if err != nil {
+			t.Fatalf("edit returned error: %v", err)
+		}
+		if line != c.expected {
+			t.Errorf("keys %q: expected %q, got %q", c.keys, c.expected, line)
+		}
+	}
+}
+
+func TestHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+
+	e := New(os.Stdin, io.Discard, path)
+	e.addHistory("map")
+	e.addHistory("map")
+	e.addHistory("  ")
+	e.addHistory("explore canalave-city-area")
+
+	reloaded := New(os.Stdin, io.Discard, path)
+	history := reloaded.History()
+	if len(history) != 2 || history[0] != "map" || history[1] != "explore canalave-city-area" {
+		t.Errorf("unexpected history %q", history)
+	}
+}
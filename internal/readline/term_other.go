@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package readline
+
+import "errors"
+
+type termState struct{}
+
+// На остальных системах редактирование строки не поддерживается,
+// Editor читает ввод построчно без истории и автодополнения
+func isTerminal(fd int) bool {
+	return false
+}
+
+func makeRaw(fd int) (*termState, error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
+
+func restore(fd int, state *termState) error {
+	return nil
+}
@@ -0,0 +1,109 @@
+// Package typechart строит таблицу эффективности типов по ресурсам /type PokeAPI
+package typechart
+
+import (
+	"sort"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+// Source - откуда загружаются типы (pokeapi.Client или подмена в тестах)
+type Source interface {
+	ListTypes() (pokeapi.NamedResourceList, error)
+	GetType(name string) (pokeapi.Type, error)
+}
+
+// Chart - таблица множителей урона атакующий тип -> защищающийся тип
+type Chart struct {
+	types []pokeapi.Type
+	// matrix[attack][defend] - множитель, отсутствие записи означает 1
+	matrix map[string]map[string]float64
+}
+
+// Load загружает все типы из src и строит таблицу
+func Load(src Source) (*Chart, error) {
+	list, err := src.ListTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	var types []pokeapi.Type
+	for _, r := range list.Results {
+		t, err := src.GetType(r.Name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+
+	return New(types), nil
+}
+
+// New строит таблицу по уже загруженным типам
+func New(types []pokeapi.Type) *Chart {
+	c := &Chart{
+		types:  types,
+		matrix: make(map[string]map[string]float64),
+	}
+	for _, t := range types {
+		c.set(t.Name, t.DamageRelations.DoubleDamageTo, 2)
+		c.set(t.Name, t.DamageRelations.HalfDamageTo, 0.5)
+		c.set(t.Name, t.DamageRelations.NoDamageTo, 0)
+	}
+	return c
+}
+
+func (c *Chart) set(attack string, defenders []pokeapi.NamedResource, multiplier float64) {
+	if c.matrix[attack] == nil {
+		c.matrix[attack] = make(map[string]float64)
+	}
+	for _, d := range defenders {
+		c.matrix[attack][d.Name] = multiplier
+	}
+}
+
+// Multiplier возвращает множитель урона приема типа attack по защитнику
+// с типами defenders (для двух типов множители перемножаются)
+func (c *Chart) Multiplier(attack string, defenders ...string) float64 {
+	result := 1.0
+	for _, d := range defenders {
+		if m, ok := c.matrix[attack][d]; ok {
+			result *= m
+		}
+	}
+	return result
+}
+
+// Effectiveness - Multiplier в виде, который принимает battle.Fight
+func (c *Chart) Effectiveness(attack string, defenders []string) float64 {
+	return c.Multiplier(attack, defenders...)
+}
+
+// Type возвращает загруженный тип по имени
+func (c *Chart) Type(name string) (pokeapi.Type, bool) {
+	for _, t := range c.types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return pokeapi.Type{}, false
+}
+
+// Defense группирует атакующие типы по множителю урона против защитника
+// с типами defenders; типы с обычным уроном (x1) не включаются
+func (c *Chart) Defense(defenders ...string) map[float64][]string {
+	result := make(map[float64][]string)
+	for _, t := range c.types {
+		// Типы без отношений (unknown, shadow) не атакуют
+		if len(c.matrix[t.Name]) == 0 {
+			continue
+		}
+		if m := c.Multiplier(t.Name, defenders...); m != 1 {
+			result[m] = append(result[m], t.Name)
+		}
+	}
+	for _, names := range result {
+		sort.Strings(names)
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package typechart
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+)
+
+func refs(names ...string) []pokeapi.NamedResource {
+	var result []pokeapi.NamedResource
+	for _, name := range names {
+		result = append(result, pokeapi.NamedResource{Name: name})
+	}
+	return result
+}
+
+// fakeSource - небольшая часть настоящей таблицы типов
+type fakeSource map[string]pokeapi.DamageRelations
+
+func (f fakeSource) ListTypes() (pokeapi.NamedResourceList, error) {
+	var list pokeapi.NamedResourceList
+	for _, name := range []string{"normal", "fire", "water", "grass", "electric", "ground", "flying", "ghost"} {
+		list.Results = append(list.Results, pokeapi.NamedResource{Name: name})
+	}
+	return list, nil
+}
+
+func (f fakeSource) GetType(name string) (pokeapi.Type, error) {
+	relations, ok := f[name]
+	if !ok {
+		return pokeapi.Type{}, errors.New("unknown type")
+	}
+	return pokeapi.Type{Name: name, DamageRelations: relations}, nil
+}
+
+var source = fakeSource{
+	"normal":   {NoDamageTo: refs("ghost")},
+	"fire":     {DoubleDamageTo: refs("grass"), HalfDamageTo: refs("fire", "water")},
+	"water":    {DoubleDamageTo: refs("fire", "ground"), HalfDamageTo: refs("water", "grass")},
+	"grass":    {DoubleDamageTo: refs("water", "ground"), HalfDamageTo: refs("fire", "grass", "flying")},
+	"electric": {DoubleDamageTo: refs("water", "flying"), HalfDamageTo: refs("electric", "grass"), NoDamageTo: refs("ground")},
+	"ground":   {DoubleDamageTo: refs("fire", "electric"), HalfDamageTo: refs("grass"), NoDamageTo: refs("flying")},
+	"flying":   {DoubleDamageTo: refs("grass"), HalfDamageTo: refs("electric")},
+	"ghost":    {DoubleDamageTo: refs("ghost"), NoDamageTo: refs("normal")},
+}
+
+func TestMultiplier(t *testing.T) {
+	chart, err := Load(source)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	cases := []struct {
+		attack    string
+		defenders []string
+		expected  float64
+	}{
+		{attack: "water", defenders: []string{"fire"}, expected: 2},
+		{attack: "fire", defenders: []string{"water"}, expected: 0.5},
+		{attack: "normal", defenders: []string{"ghost"}, expected: 0},
+		{attack: "normal", defenders: []string{"water"}, expected: 1},
+		{attack: "water", defenders: []string{"fire", "ground"}, expected: 4},
+		{attack: "grass", defenders: []string{"water", "flying"}, expected: 1},
+		{attack: "electric", defenders: []string{"water", "ground"}, expected: 0},
+	}
+
+	for _, c := range cases {
+		if got := chart.Multiplier(c.attack, c.defenders...); got != c.expected {
+			t.Errorf("Multiplier(%s, %v): expected %v, got %v", c.attack, c.defenders, c.expected, got)
+		}
+	}
+}
+
+func TestDefense(t *testing.T) {
+	chart, err := Load(source)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// water/ground: grass x4, electric x0, fire x0.5 (x0.5 от water, x1 от ground)
+	defense := chart.Defense("water", "ground")
+	expected := map[float64][]string{
+		4:   {"grass"},
+		0:   {"electric"},
+		0.5: {"fire"},
+	}
+	if !reflect.DeepEqual(defense, expected) {
+		t.Errorf("expected %v, got %v", expected, defense)
+	}
+}
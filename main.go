@@ -1,95 +1,108 @@
 package main
 
 import (
-	"bufio"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"math/rand"
-	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/IdrisovMarat/pokemon/internal/capture"
+	"github.com/IdrisovMarat/pokemon/internal/encounter"
+	"github.com/IdrisovMarat/pokemon/internal/inventory"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
 	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+	"github.com/IdrisovMarat/pokemon/internal/readline"
+	"github.com/IdrisovMarat/pokemon/internal/typechart"
 )
 
-var baseUrl = "https://pokeapi.co/api/v2/location-area/"
-
-var cache *pokecache.Cache
-var pokedex *pokecache.Pokedex
-
-func init() {
-	// Инициализируем кэш с интервалом 1 минута
-	cache = pokecache.NewCache(45 * time.Second)
-	pokedex = pokecache.NewPokedex()
-
-}
-
-type pokemonJson struct {
-	ID                     int    `json:"id"`
-	Name                   string `json:"name"`
-	BaseExperience         int    `json:"base_experience"`
-	Height                 int    `json:"height"`
-	IsDefault              bool   `json:"is_default"`
-	Order                  int    `json:"order"`
-	Weight                 int    `json:"weight"`
-	Abilities              []any  `json:"abilities"`
-	Forms                  []any  `json:"forms"`
-	GameIndices            []any  `json:"game_indices"`
-	HeldItems              []any  `json:"held_items"`
-	LocationAreaEncounters string `json:"location_area_encounters"`
-	Moves                  []any  `json:"moves"`
-	Species                any    `json:"species"`
-	Sprites                any    `json:"sprites"`
-	Cries                  any    `json:"cries"`
-	Stats                  []any  `json:"stats"`
-	Types                  []any  `json:"types"`
-	PastTypes              []any  `json:"past_types"`
-	PastAbilities          []any  `json:"past_abilities"`
+// defaultPokedexPath возвращает путь к файлу сохранения в каталоге конфигурации пользователя
+func defaultPokedexPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "pokedex.json"
+	}
+	return filepath.Join(dir, "pokemon", "pokedex.json")
 }
 
-type locationAreaJson struct {
-	EncounterMethodRates []any               `json:"encounter_method_rates"`
-	GameIndex            int                 `json:"game_index"`
-	ID                   int                 `json:"id"`
-	Location             any                 `json:"location"`
-	Name                 string              `json:"name"`
-	Names                []any               `json:"names"`
-	PokemonEncounters    []PokemonEncounters `json:"pokemon_encounters"`
+// bagPath возвращает файл сумки рядом с файлом Pokedex: pokedex.json -> pokedex.bag.json
+func bagPath(pokedexPath string) string {
+	return strings.TrimSuffix(pokedexPath, filepath.Ext(pokedexPath)) + ".bag.json"
 }
 
-type Pokemon struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
+// loadBag читает сумку, сохраненную рядом с Pokedex.
+// Стартовая сумка выдается, только если сохраненной еще нет
+func loadBag(pokedexPath string) (*inventory.Inventory, error) {
+	bag, err := inventory.Load(bagPath(pokedexPath))
+	if errors.Is(err, fs.ErrNotExist) {
+		return inventory.NewStarter(), nil
+	}
+	return bag, err
 }
 
-type PokemonEncounters struct {
-	Pokemon        Pokemon `json:"pokemon"`
-	VersionDetails []any   `json:"version_details"`
+// defaultHistoryPath возвращает путь к файлу истории команд REPL
+func defaultHistoryPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokemon", "history")
 }
 
-type locationJson struct {
-	Count    int       `json:"count"`
-	Next     *string   `json:"next"`
-	Previous *string   `json:"previous"`
-	Results  []Results `json:"results"`
-}
-type Results struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
+// defaultCacheDir возвращает каталог дискового кэша ответов PokeAPI
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokemon")
 }
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(*config, string) error
+	callback    func(*config, ...string) error
 }
 
+// config - состояние сессии, которое получает каждая команда
 type config struct {
 	next     *string
 	previous *string
 	offset   int
 	limit    int
+
+	cache   pokecache.Cache
+	client  pokeapi.Client
+	pokedex *pokecache.Pokedex
+	seen    sessionNames
+	// area - текущая локация тренера, задается explore и goto
+	area *pokeapi.LocationArea
+	// wild - последний встреченный командой walk дикий покемон
+	wild *encounter.Wild
+	// version - версия игры, по которой фильтруются встречи (пустая - все версии)
+	version string
+	// sandbox разрешает ловить любого покемона вне зависимости от локации
+	sandbox bool
+	// inventory - покеболы тренера, сохраняются рядом с Pokedex
+	inventory *inventory.Inventory
+	// catchMode - catchModeGames (по умолчанию) или catchModeLegacy
+	catchMode string
+	// rng - единый источник случайности сессии, см. random
+	rng *rand.Rand
+	// speciesTotal - число видов в PokeAPI для прогресса Pokedex, см. pokedexProgress.
+	// -1, если в этой сессии узнать его не удалось
+	speciesTotal int
+	// growthRates - загруженные кривые опыта по имени, см. getGrowthRate
+	growthRates map[string]pokeapi.GrowthRate
+	// typeChart загружается при первом обращении, см. getTypeChart
+	typeChart *typechart.Chart
+	// pokedexPath - файл, в который сохраняется Pokedex между сессиями
+	pokedexPath string
 }
 
 var pageConfig = config{
@@ -99,21 +112,45 @@ var pageConfig = config{
 	limit:    20,
 }
 
-// Вспомогательная функция для обработки данных из кэша
-func processCachedData(cachedData []byte, cfg *config) error {
-	var location locationJson
-	err := json.Unmarshal(cachedData, &location)
-	if err != nil {
-		return err
+// random возвращает источник случайности сессии.
+// Если он не задан флагом --seed, создается от текущего времени
+func (cfg *config) random() *rand.Rand {
+	if cfg.rng == nil {
+		cfg.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 	}
+	return cfg.rng
+}
 
+// showLocationPage выводит страницу location-area и обновляет конфигурацию
+func showLocationPage(location pokeapi.LocationAreaList, cfg *config) error {
 	// Обновляем конфигурацию
 	cfg.next = location.Next
 	cfg.previous = location.Previous
 
+	names := make([]string, 0, len(location.Results))
+	if cfg.version == "" {
+		for _, k := range location.Results {
+			names = append(names, k.Name)
+		}
+	} else {
+		// В списке нет версий, поэтому для фильтра нужны сами локации.
+		// Загружаем их до вывода и без сообщений о кэше, чтобы они не перемешались со списком
+		client := cfg.client.Quiet()
+		for _, k := range location.Results {
+			area, err := client.GetLocationArea(k.Name)
+			if err != nil {
+				return err
+			}
+			if encounter.HasVersion(area, cfg.version) {
+				names = append(names, k.Name)
+			}
+		}
+	}
+
 	// Выводим результаты
-	for _, k := range location.Results {
-		fmt.Println(k.Name)
+	for _, name := range names {
+		fmt.Println(name)
+		cfg.seen.addArea(name)
 	}
 
 	// Увеличиваем offset для следующего вызова
@@ -122,23 +159,47 @@ func processCachedData(cachedData []byte, cfg *config) error {
 	return nil
 }
 
-func commandExit(cfg *config, s string) error {
+// errExit возвращает команда exit, чтобы цикл команд завершился
+var errExit = errors.New("exit")
+
+func commandExit(cfg *config, args ...string) error {
 	fmt.Println("Closing the Pokedex... Goodbye!")
-	cache.Stop()
-	os.Exit(0)
-	if os.ErrClosed != nil {
-		return os.ErrClosed
+	return errExit
+}
+
+// shutdown сохраняет Pokedex и сумку и останавливает кэш перед выходом
+func shutdown(cfg *config) {
+	if err := cfg.pokedex.Save(cfg.pokedexPath); err != nil {
+		fmt.Println("failed to save the Pokedex:", err)
 	}
-	return nil
+	if err := cfg.inventory.Save(bagPath(cfg.pokedexPath)); err != nil {
+		fmt.Println("failed to save the bag:", err)
+	}
+	cfg.cache.Stop()
 }
 
+// defaultCatchLevel - уровень покемона, пойманного не из встречи walk
+const defaultCatchLevel = 5
+
+// pokemonLevel возвращает уровень экземпляра; у старых сохранений он не записан
+func pokemonLevel(p pokecache.Pokemonmain) int {
+	if p.Level == 0 {
+		return defaultCatchLevel
+	}
+	return p.Level
+}
+
+// Режимы поимки: по формуле игр (capture_rate вида) или старый по базовому опыту
+const (
+	catchModeGames  = "games"
+	catchModeLegacy = "legacy"
+)
+
 // CatchPokemon пытается поймать покемона с учетом его базового опыта
 // baseExp - базовый опыт покемона (чем выше, тем сложнее поймать)
+// rng - источник случайности сессии
 // возвращает true если покемон пойман, false если нет
-func CatchPokemon(baseExp int) bool {
-
-	// Инициализируем генератор случайных чисел
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+func CatchPokemon(rng *rand.Rand, baseExp int) bool {
 
 	// Определяем базовую вероятность поимки (можно настроить)
 	baseCatchRate := 0.7 // 70% базовая вероятность
@@ -165,189 +226,359 @@ func CatchPokemon(baseExp int) bool {
 	return randomValue <= catchProbability
 }
 
-func commandCatch(cfg *config, pokemon string) error {
-	baseUrl = "https://pokeapi.co/api/v2/pokemon/"
-	// Формируем URL с текущим offset и limit
-	url := fmt.Sprintf("%s/%s/", baseUrl, pokemon)
-
-	// Проверяем кэш
-	// if cachedData, found := cache.Get(url); found {
-	// 	fmt.Println("...USING CACHE DATA...")
-	// 	return processCachedData(cachedData, cfg)
-	// }
-
-	client := http.Client{
-		Timeout: 10 * time.Second,
+// commandCatch бросает покебол в покемона
+// опция --ball=poke|great|ultra|master выбирает покебол из сумки
+// (кроме режима legacy, в котором вид покебола не учитывается)
+func commandCatch(cfg *config, args ...string) error {
+	opts, rest := parseOptions(args)
+	if len(rest) == 0 {
+		return errors.New("you must provide a pokemon name")
+	}
+	pokemon := rest[0]
+
+	ball := inventory.DefaultBall
+	if name, ok := opts["ball"]; ok {
+		// Иначе, например, Master Ball потратился бы без всякого эффекта
+		if cfg.catchMode == catchModeLegacy {
+			return fmt.Errorf("--ball is not supported with --catch-mode=%s", catchModeLegacy)
+		}
+		ball, ok = inventory.LookupBall(name)
+		if !ok {
+			return fmt.Errorf("unknown ball: %s", name)
+		}
 	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
+	nickname := opts["nickname"]
+	if err := validateNickname(cfg, nickname, pokecache.Pokemonmain{Name: pokemon}); err != nil {
 		return err
 	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+	if cfg.inventory.Count(ball.Name) == 0 {
+		return fmt.Errorf("you have no %ss left", ball.Label)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP error: %s", resp.Status)
+	// Вне локации ловить некого - отказываем без запросов к PokeAPI
+	if !cfg.sandbox && cfg.area == nil {
+		return errors.New("you are not in any location area, use explore or goto first")
 	}
 
-	var pokemonmain pokemonJson
-
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&pokemonmain)
+	pokemonmain, err := cfg.client.GetPokemon(pokemon)
 	if err != nil {
 		return err
 	}
+	if !cfg.sandbox && !livesInArea(cfg, pokemonmain.Name) {
+		return fmt.Errorf("%s cannot be found in %s", pokemonmain.Name, cfg.area.Name)
+	}
 
-	// responseData, err := json.Marshal(locationArea)
-	// if err != nil {
-	// 	return err
-	// }
-
-	// // Сохраняем в кэш
-	// cache.Add(url, responseData)
-	// fmt.Println("...DATA CACHED...")
+	// Все, что может не удаться, загружаем до броска, чтобы не потратить мяч зря
+	var species pokeapi.PokemonSpecies
+	if cfg.catchMode != catchModeLegacy {
+		species, err = getSpecies(cfg, pokemonmain)
+		if err != nil {
+			return err
+		}
+	}
 
-	// // Обновляем конфигурацию
-	// cfg.next = location.Next
-	// cfg.previous = location.Previous
+	cfg.inventory.Use(ball.Name)
+	fmt.Printf("Throwing a %s at %s...\n", ball.Label, pokemonmain.Name)
 
-	// pokedex := make(map[string]Pokemon)
-	fmt.Printf("Throwing a Pokeball at %s...", pokemonmain.Name)
-	experience := pokemonmain.BaseExperience
+	var caught bool
+	if cfg.catchMode == catchModeLegacy {
+		// Старая формула не учитывает вид покебола
+		caught = CatchPokemon(cfg.random(), pokemonmain.BaseExperience)
+	} else {
+		caught = throwBall(cfg, pokemonmain, species, ball)
+	}
 
-	if CatchPokemon(experience) {
-		pokedex.Add(pokemonmain.Name)
-		fmt.Printf("\n%s was caught!", pokemonmain.Name)
+	if caught {
+		entry := toPokedexEntry(pokemonmain)
+		entry.Nickname = nickname
+		level := defaultCatchLevel
+		if cfg.wild != nil && cfg.wild.Pokemon == pokemonmain.Name {
+			// Пойман покемон из встречи walk - с ее уровнем
+			level = cfg.wild.Level
+			cfg.wild = nil
+		}
+		setLevel(&entry, level)
+		if cfg.area != nil {
+			entry.Area = cfg.area.Name
+		}
+		entry = cfg.pokedex.Add(entry)
+		fmt.Printf("%s was caught! (#%d)\n", pokemonmain.Name, entry.ID)
+		if entry.Box != 0 {
+			fmt.Printf("Your party is full, %s was sent to box %d\n", pokemonmain.Name, entry.Box)
+		}
 	} else {
-		fmt.Printf("\n%s escaped!", pokemonmain.Name)
+		cfg.pokedex.MarkSeen(pokemonmain.Name)
+		fmt.Printf("%s escaped!\n", pokemonmain.Name)
 	}
 
 	return nil
 }
 
-func commandExplore(cfg *config, loc string) error {
-	// Формируем URL с текущим offset и limit
-	url := fmt.Sprintf("%s/%s/", baseUrl, loc)
-
-	// Проверяем кэш
-	// if cachedData, found := cache.Get(url); found {
-	// 	fmt.Println("...USING CACHE DATA...")
-	// 	return processCachedData(cachedData, cfg)
-	// }
-
-	client := http.Client{
-		Timeout: 10 * time.Second,
+// getSpecies загружает вид покемона (у форм вроде deoxys-attack он отличается от имени)
+func getSpecies(cfg *config, pokemon pokeapi.Pokemon) (pokeapi.PokemonSpecies, error) {
+	speciesName := pokemon.Species.Name
+	if speciesName == "" {
+		speciesName = pokemon.Name
 	}
+	return cfg.client.GetPokemonSpecies(speciesName)
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
+// throwBall бросает мяч по формуле игр с capture_rate вида.
+// HP дикого покемона из встречи walk берется из боя с ним (см. commandBattle),
+// остальные - с полным HP. Статусов в бою нет, поэтому цель всегда без статуса
+func throwBall(cfg *config, pokemon pokeapi.Pokemon, species pokeapi.PokemonSpecies, ball inventory.Ball) bool {
+	maxHP := 1
+	for _, s := range pokemon.Stats {
+		if s.Stat.Name == "hp" {
+			maxHP = s.BaseStat
+		}
 	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+	currentHP := maxHP
+	if cfg.wild != nil && cfg.wild.Pokemon == pokemon.Name && cfg.wild.HP > 0 {
+		maxHP, currentHP = cfg.wild.MaxHP, cfg.wild.HP
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP error: %s", resp.Status)
+	result := capture.Throw(capture.Attempt{
+		CaptureRate:  species.CaptureRate,
+		BallModifier: ball.Modifier,
+		MaxHP:        maxHP,
+		CurrentHP:    currentHP,
+		Status:       capture.StatusNone,
+	}, cfg.random())
+
+	// Четвертая проверка - это щелчок мяча, встряски видно только три
+	if wobbles := min(result.Shakes, 3); wobbles > 0 {
+		fmt.Println(strings.TrimSpace(strings.Repeat("wobble… ", wobbles)))
 	}
 
-	var locationArea locationAreaJson
+	return result.Caught
+}
 
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&locationArea)
-	if err != nil {
-		return err
+// commandInventory показывает, что осталось в сумке
+func commandInventory(cfg *config, args ...string) error {
+	items := cfg.inventory.Items()
+	if len(items) == 0 {
+		fmt.Println("Your bag is empty")
+		return nil
 	}
 
-	// responseData, err := json.Marshal(locationArea)
-	// if err != nil {
-	// 	return err
-	// }
+	fmt.Println("Your bag:")
+	for _, item := range items {
+		label := item.Name
+		if ball, ok := inventory.LookupBall(item.Name); ok {
+			label = ball.Label
+		}
+		fmt.Printf(" - %s x%d\n", label, item.Count)
+	}
+	return nil
+}
+
+// toPokedexEntry переводит ответ PokeAPI в запись Pokedex
+func toPokedexEntry(p pokeapi.Pokemon) pokecache.Pokemonmain {
+	entry := pokecache.Pokemonmain{
+		Name:           p.Name,
+		Species:        p.Species.Name,
+		Height:         p.Height,
+		Weight:         p.Weight,
+		BaseExperience: p.BaseExperience,
+	}
+	for _, s := range p.Stats {
+		entry.Stats = append(entry.Stats, pokecache.Stat{Name: s.Stat.Name, BaseStat: s.BaseStat})
+	}
+	for _, t := range p.Types {
+		entry.Types = append(entry.Types, t.Type.Name)
+	}
+	for _, m := range p.Moves {
+		entry.Moves = append(entry.Moves, m.Move.Name)
+	}
+	return entry
+}
 
-	// // Сохраняем в кэш
-	// cache.Add(url, responseData)
-	// fmt.Println("...DATA CACHED...")
+func commandInspect(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide a pokemon name")
+	}
 
-	// // Обновляем конфигурацию
-	// cfg.next = location.Next
-	// cfg.previous = location.Previous
+	pokemon, ok := cfg.pokedex.Get(args[0])
+	if !ok {
+		return fmt.Errorf("you have not caught %s", args[0])
+	}
 
-	for _, k := range locationArea.PokemonEncounters {
-		fmt.Println(k.Pokemon.Name)
+	fmt.Printf("Name: %s (#%d)\n", pokemon.Name, pokemon.ID)
+	if pokemon.Nickname != "" {
+		fmt.Printf("Nickname: %s\n", pokemon.Nickname)
+	}
+	fmt.Printf("Level: %d\n", pokemonLevel(pokemon))
+	if pokemon.Experience > 0 {
+		fmt.Printf("Experience: %d\n", pokemon.Experience)
+	}
+	if pokemon.Area != "" {
+		fmt.Printf("Caught in: %s\n", pokemon.Area)
+	}
+	fmt.Printf("Caught at: %s\n", pokemon.CreatedAt.Format(time.DateTime))
+	fmt.Printf("Height: %d\n", pokemon.Height)
+	fmt.Printf("Weight: %d\n", pokemon.Weight)
+	fmt.Printf("Base experience: %d\n", pokemon.BaseExperience)
+	fmt.Println("Stats:")
+	for _, s := range pokemon.Stats {
+		fmt.Printf("  -%s: %d\n", s.Name, s.BaseStat)
+	}
+	stats := pokemon.LevelStats
+	if len(stats) == 0 {
+		// Старые сохранения без рассчитанных характеристик
+		stats = levelStats(pokemon.Stats, pokemonLevel(pokemon))
+	}
+	fmt.Printf("Stats at level %d:\n", pokemonLevel(pokemon))
+	for _, s := range stats {
+		fmt.Printf("  -%s: %d\n", s.Name, s.BaseStat)
+	}
+	fmt.Println("Types:")
+	for _, t := range pokemon.Types {
+		fmt.Printf("  - %s\n", t)
 	}
 
 	return nil
 }
 
-func commandMap(cfg *config, s string) error {
-	// Формируем URL с текущим offset и limit
-	url := fmt.Sprintf("%s?offset=%d&limit=%d", baseUrl, cfg.offset, cfg.limit)
+// parseOptions отделяет опции вида --key=value от обычных аргументов
+func parseOptions(args []string) (map[string]string, []string) {
+	opts := make(map[string]string)
+	var rest []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			rest = append(rest, arg)
+			continue
+		}
+		key, value, _ := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		opts[key] = value
+	}
+	return opts, rest
+}
 
-	// Проверяем кэш
-	if cachedData, found := cache.Get(url); found {
-		fmt.Println("...USING CACHE DATA...")
-		return processCachedData(cachedData, cfg)
+func hasType(pokemon pokecache.Pokemonmain, typeName string) bool {
+	for _, t := range pokemon.Types {
+		if t == typeName {
+			return true
+		}
 	}
+	return false
+}
 
-	client := http.Client{
-		Timeout: 10 * time.Second,
+// pokedexProgress выводит, сколько видов встречено и поймано.
+// Общее число видов запрашивается один раз за сессию; если PokeAPI недоступен,
+// оно не выводится и повторно не запрашивается, чтобы не ждать таймаут снова
+func pokedexProgress(cfg *config) {
+	if cfg.speciesTotal == 0 {
+		cfg.speciesTotal = -1
+		if list, err := cfg.client.ListPokemonSpecies(0, 1); err == nil && list.Count > 0 {
+			cfg.speciesTotal = list.Count
+		}
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
+	seen := len(cfg.pokedex.SeenList())
+	caught := len(cfg.pokedex.CaughtList())
+	if cfg.speciesTotal < 0 {
+		fmt.Printf("Seen: %d, caught: %d\n", seen, caught)
+		return
 	}
+	fmt.Printf("Seen: %d/%d, caught: %d/%d\n", seen, cfg.speciesTotal, caught, cfg.speciesTotal)
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+// commandPokedex выводит каталог пойманных видов и сколько у тренера их экземпляров
+// (сами экземпляры показывают party и box).
+// Опции: --sort=name|caught|exp и --type=<type>
+func commandPokedex(cfg *config, args ...string) error {
+	opts, _ := parseOptions(args)
+
+	pokedexProgress(cfg)
+
+	list := cfg.pokedex.List()
+
+	if typeName, ok := opts["type"]; ok {
+		filtered := list[:0]
+		for _, pokemon := range list {
+			if hasType(pokemon, typeName) {
+				filtered = append(filtered, pokemon)
+			}
+		}
+		list = filtered
+	}
+
+	// List уже отсортирован по имени
+	switch opts["sort"] {
+	case "", "name":
+	case "caught":
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		})
+	case "exp":
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].BaseExperience < list[j].BaseExperience
+		})
+	default:
+		return fmt.Errorf("unknown sort order: %s", opts["sort"])
+	}
+
+	if len(list) == 0 {
+		fmt.Println("Your Pokedex is empty")
+		return nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP error: %s", resp.Status)
+	counts := make(map[string]int)
+	for _, s := range cfg.pokedex.Species() {
+		counts[s.Name] = s.Count
 	}
 
-	var location locationJson
+	fmt.Println("Your Pokedex:")
+	// Вид выводится один раз, на месте первого экземпляра в порядке сортировки
+	listed := make(map[string]bool)
+	for _, pokemon := range list {
+		if listed[pokemon.Name] {
+			continue
+		}
+		listed[pokemon.Name] = true
+		fmt.Printf(" - %s x%d\n", pokemon.Name, counts[pokemon.Name])
+	}
 
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&location)
-	if err != nil {
-		return err
+	return nil
+}
+
+func commandExplore(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide a location name")
 	}
+	loc := args[0]
 
-	responseData, err := json.Marshal(location)
+	locationArea, err := enterArea(cfg, loc)
 	if err != nil {
 		return err
 	}
 
-	// Сохраняем в кэш
-	cache.Add(url, responseData)
-	fmt.Println("...DATA CACHED...")
+	for _, k := range locationArea.PokemonEncounters {
+		if !encounter.Appears(k, cfg.version) {
+			continue
+		}
+		if cfg.version == "" {
+			fmt.Printf("%s (%s)\n", k.Pokemon.Name, strings.Join(encounter.Versions(k), ", "))
+		} else {
+			fmt.Println(k.Pokemon.Name)
+		}
+		cfg.seen.addPokemon(k.Pokemon.Name)
+		cfg.pokedex.MarkSeen(k.Pokemon.Name)
+	}
 
-	// Обновляем конфигурацию
-	cfg.next = location.Next
-	cfg.previous = location.Previous
+	return nil
+}
 
-	for _, k := range location.Results {
-		fmt.Println(k.Name)
+func commandMap(cfg *config, args ...string) error {
+	location, err := cfg.client.ListLocationAreas(cfg.offset, cfg.limit)
+	if err != nil {
+		return err
 	}
 
-	// Увеличиваем offset для следующего вызова
-	cfg.offset += cfg.limit
-
-	return nil
+	return showLocationPage(location, cfg)
 }
 
-func commandMapb(cfg *config, s string) error {
+func commandMapb(cfg *config, args ...string) error {
 	// Проверяем, можно ли идти назад
 	if cfg.offset <= cfg.limit {
 		fmt.Println("You're on the first page. Cannot go back.")
@@ -360,57 +591,118 @@ func commandMapb(cfg *config, s string) error {
 		cfg.offset = 0
 	}
 
-	// Формируем URL
-	url := fmt.Sprintf("%s?offset=%d&limit=%d", baseUrl, cfg.offset, cfg.limit)
-
-	// Проверяем кэш
-	if cachedData, found := cache.Get(url); found {
-		fmt.Println("...USING CACHE DATA...")
-		return processCachedData(cachedData, cfg)
+	location, err := cfg.client.ListLocationAreas(cfg.offset, cfg.limit)
+	if err != nil {
+		return err
 	}
 
-	client := http.Client{
-		Timeout: 10 * time.Second,
+	// showLocationPage снова увеличит offset (если будут вызывать map)
+	return showLocationPage(location, cfg)
+}
+
+// commandSave сохраняет Pokedex в файл (по умолчанию в pokedexPath), а сумку - рядом
+func commandSave(cfg *config, args ...string) error {
+	path := cfg.pokedexPath
+	if len(args) > 0 {
+		path = args[0]
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
+	if err := cfg.pokedex.Save(path); err != nil {
 		return err
 	}
-
-	resp, err := client.Do(req)
-	if err != nil {
+	if err := cfg.inventory.Save(bagPath(path)); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	fmt.Printf("Pokedex saved to %s\n", path)
+	return nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP error: %s", resp.Status)
+// commandLoad заменяет текущий Pokedex содержимым файла, а сумку - сохраненной рядом
+func commandLoad(cfg *config, args ...string) error {
+	path := cfg.pokedexPath
+	if len(args) > 0 {
+		path = args[0]
 	}
 
-	var location locationJson
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&location)
+	// LoadPokedex считает отсутствующий файл пустым Pokedex - это нужно только при запуске.
+	// Здесь опечатка в пути заменила бы текущий Pokedex пустым, а shutdown сохранил бы его
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	loaded, err := pokecache.LoadPokedex(path)
 	if err != nil {
 		return err
 	}
+	// Без файла сумки (старые сохранения) остается текущая
+	bag, err := inventory.Load(bagPath(path))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	cfg.pokedex = loaded
+	if bag != nil {
+		cfg.inventory = bag
+	}
+	fmt.Printf("Pokedex loaded from %s (%d pokemons)\n", path, cfg.pokedex.Len())
+	return nil
+}
 
-	// Обновляем конфигурацию
-	cfg.next = location.Next
-	cfg.previous = location.Previous
+// commandCache показывает и чистит кэш ответов PokeAPI
+// подкоманды: stats (по умолчанию), keys, drop <key>, clear
+func commandCache(cfg *config, args ...string) error {
+	inspector, ok := cfg.cache.(pokecache.Inspector)
+	if !ok {
+		return errors.New("the cache does not support inspection")
+	}
 
-	// Выводим результаты
-	for _, k := range location.Results {
-		fmt.Println(k.Name)
+	sub := "stats"
+	if len(args) > 0 {
+		sub = args[0]
 	}
 
-	// Увеличиваем offset для следующего вызова (если будут вызывать map)
-	cfg.offset += cfg.limit
+	switch sub {
+	case "stats":
+		stats := inspector.Stats()
+		hitRate := 0.0
+		if total := stats.Hits + stats.Misses; total > 0 {
+			hitRate = float64(stats.Hits) / float64(total) * 100
+		}
+		fmt.Println("Cache stats:")
+		fmt.Printf("  entries:   %d\n", stats.Entries)
+		fmt.Printf("  bytes:     %d\n", stats.Bytes)
+		fmt.Printf("  hits:      %d\n", stats.Hits)
+		fmt.Printf("  misses:    %d\n", stats.Misses)
+		fmt.Printf("  hit rate:  %.1f%%\n", hitRate)
+		fmt.Printf("  evictions: %d\n", stats.Evictions)
+		fmt.Printf("  reaped:    %d\n", stats.Reaped)
+	case "keys":
+		keys := inspector.Keys()
+		if len(keys) == 0 {
+			fmt.Println("The cache is empty")
+			return nil
+		}
+		for _, k := range keys {
+			fmt.Printf("%s (age %s, %d bytes)\n", k.Key, k.Age.Round(time.Second), k.Size)
+		}
+	case "drop":
+		if len(args) < 2 {
+			return errors.New("you must provide a cache key")
+		}
+		if !inspector.Delete(args[1]) {
+			fmt.Println("no such key in the cache")
+			return nil
+		}
+		fmt.Printf("%s dropped\n", args[1])
+	case "clear":
+		inspector.Clear()
+		fmt.Println("...ALL CACHED DATA DELETED...")
+	default:
+		return fmt.Errorf("unknown cache subcommand: %s", sub)
+	}
 
 	return nil
 }
 
-func commandHelp(cfg *config, s string) error {
+func commandHelp(cfg *config, args ...string) error {
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Println("Usage:")
 	fmt.Println()
@@ -418,14 +710,36 @@ func commandHelp(cfg *config, s string) error {
 	fmt.Println("exit: Exit the Pokedex")
 	fmt.Println("map: Display next 20 location areas")
 	fmt.Println("mapb: Display previous 20 location areas")
+	fmt.Println("explore <area>: Go to the location area and list its pokemons")
+	fmt.Println("version [name|all]: Filter encounters by game version or show the current filter")
+	fmt.Println("goto [area]: Go to the location area or show the current one")
+	fmt.Println("walk [--method=walk|surf|old-rod|...]: Look for a wild pokemon in the current area")
+	fmt.Println("catch <pokemon> [--ball=poke|great|ultra|master] [--nickname=<name>]: Try to catch a pokemon of the current area")
+	fmt.Println("nickname <pokemon> [name]: Give a caught pokemon a nickname or remove it")
+	fmt.Println("inventory: List the balls left in your bag")
+	fmt.Println("inspect <pokemon>: Show details of a caught pokemon (by name, #id or nickname)")
+	fmt.Println("pokedex [--sort=name|caught|exp] [--type=<type>]: Show seen/caught counts and the caught species")
+	fmt.Println("party: List the pokemons of your party")
+	fmt.Println("box [n]: List your PC boxes or the pokemons of box n")
+	fmt.Println("deposit <pokemon> [box]: Move a party pokemon to a PC box")
+	fmt.Println("withdraw <pokemon>: Move a pokemon from its PC box to your party")
+	fmt.Println("swap <pokemon> <pokemon>: Swap two pokemons in your party or between party and box")
+	fmt.Println("reorder <pokemon> <slot>: Move a party pokemon to a slot")
+	fmt.Println("save [file]: Save the Pokedex and the bag")
+	fmt.Println("load [file]: Load the Pokedex and the bag")
+	fmt.Println("cache [stats|keys|drop <key>|clear]: Inspect the response cache")
+	fmt.Println("evolutions <species>: Show the evolution chain of a species")
+	fmt.Println("evolve <pokemon> [--into=<species>]: Evolve a caught pokemon if its conditions are met")
+	fmt.Println("battle [mine] [opponent]: Battle two party pokemons or the wild one met by walk, the winner gains experience")
+	fmt.Println("type <type> [<type>]: Show strengths, weaknesses and immunities of a type")
 	fmt.Println()
 
 	return nil
 }
 
-func main() {
-
-	var commands = map[string]cliCommand{
+// getCommands возвращает все команды REPL
+func getCommands() map[string]cliCommand {
+	return map[string]cliCommand{
 		"exit": {
 			name:        "exit",
 			description: "Exit the Pokedex",
@@ -451,44 +765,202 @@ func main() {
 			description: "lists pokemons of the location area",
 			callback:    commandExplore,
 		},
+		"goto": {
+			name:        "goto",
+			description: "moves to the location area",
+			callback:    commandGoto,
+		},
+		"walk": {
+			name:        "walk",
+			description: "rolls a wild pokemon encounter in the current area",
+			callback:    commandWalk,
+		},
+		"version": {
+			name:        "version",
+			description: "sets the game version encounters are filtered by",
+			callback:    commandVersion,
+		},
 		"catch": {
 			name:        "catch",
 			description: "trying to catch pokemon",
 			callback:    commandCatch,
 		},
+		"inventory": {
+			name:        "inventory",
+			description: "lists the balls left in the bag",
+			callback:    commandInventory,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "sets the nickname of a caught pokemon",
+			callback:    commandNickname,
+		},
+		"inspect": {
+			name:        "inspect",
+			description: "shows details of a caught pokemon",
+			callback:    commandInspect,
+		},
+		"pokedex": {
+			name:        "pokedex",
+			description: "lists caught pokemons",
+			callback:    commandPokedex,
+		},
+		"party": {
+			name:        "party",
+			description: "lists the pokemons of the party",
+			callback:    commandParty,
+		},
+		"box": {
+			name:        "box",
+			description: "lists the PC boxes",
+			callback:    commandBox,
+		},
+		"deposit": {
+			name:        "deposit",
+			description: "moves a party pokemon to a PC box",
+			callback:    commandDeposit,
+		},
+		"withdraw": {
+			name:        "withdraw",
+			description: "moves a pokemon from a PC box to the party",
+			callback:    commandWithdraw,
+		},
+		"swap": {
+			name:        "swap",
+			description: "swaps two pokemons between party slots and boxes",
+			callback:    commandSwap,
+		},
+		"reorder": {
+			name:        "reorder",
+			description: "moves a party pokemon to a slot",
+			callback:    commandReorder,
+		},
+		"save": {
+			name:        "save",
+			description: "saves the Pokedex and the bag to disk",
+			callback:    commandSave,
+		},
+		"load": {
+			name:        "load",
+			description: "loads the Pokedex and the bag from disk",
+			callback:    commandLoad,
+		},
+		"cache": {
+			name:        "cache",
+			description: "shows cache statistics and manages cached data",
+			callback:    commandCache,
+		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "shows the evolution chain of a species",
+			callback:    commandEvolutions,
+		},
+		"evolve": {
+			name:        "evolve",
+			description: "evolves a caught pokemon",
+			callback:    commandEvolve,
+		},
+		"battle": {
+			name:        "battle",
+			description: "runs a turn-based battle between two caught pokemons",
+			callback:    commandBattle,
+		},
+		"type": {
+			name:        "type",
+			description: "shows the damage relations of a type",
+			callback:    commandType,
+		},
 	}
+}
 
-	scanner := bufio.NewScanner(os.Stdin)
-
-	defer cache.Stop()
-
-	for {
-		fmt.Print("\nPokedex > ")
+func main() {
+	cfg := pageConfig
+	flag.StringVar(&cfg.pokedexPath, "pokedex", defaultPokedexPath(), "file the Pokedex is saved to")
+	cacheDir := flag.String("cache-dir", defaultCacheDir(), "directory for the disk cache of PokeAPI responses (empty disables it)")
+	cacheTTL := flag.Duration("cache-ttl", 7*24*time.Hour, "how long PokeAPI responses are kept in the disk cache")
+	cacheMaxEntries := flag.Int("cache-max-entries", 0, "max number of responses kept in memory (0 is unlimited)")
+	cacheMaxBytes := flag.Int("cache-max-bytes", 0, "max total size of responses kept in memory (0 is unlimited)")
+	flag.StringVar(&cfg.catchMode, "catch-mode", catchModeGames, "catch formula: games (species capture rate) or legacy (base experience)")
+	flag.StringVar(&cfg.version, "version", "", "game version to filter encounters by, e.g. platinum (empty shows all versions)")
+	flag.BoolVar(&cfg.sandbox, "sandbox", false, "allow catching any pokemon, not only those of the current location area")
+	seed := flag.Int64("seed", 0, "seed for all random game mechanics, the same seed replays the same results (0 picks a random seed)")
+	script := flag.String("c", "", "run commands separated by ';' and exit")
+	scriptFile := flag.String("f", "", "run commands from a file, one per line, and exit")
+	historyPath := flag.String("history", defaultHistoryPath(), "file the REPL command history is kept in (empty keeps it in memory)")
+	offlineDir := flag.String("offline", os.Getenv("POKEAPI_DATA_DIR"), "read PokeAPI data from a local api-data dump instead of the network (also POKEAPI_DATA_DIR)")
+	flag.Parse()
+
+	if cfg.catchMode != catchModeGames && cfg.catchMode != catchModeLegacy {
+		fmt.Println("unknown catch mode:", cfg.catchMode)
+		os.Exit(2)
+	}
+
+	pokedex, err := pokecache.LoadPokedex(cfg.pokedexPath)
+	if err != nil {
+		fmt.Println("failed to load the Pokedex:", err)
+		os.Exit(1)
+	}
+	cfg.pokedex = pokedex
+	cfg.inventory, err = loadBag(cfg.pokedexPath)
+	if err != nil {
+		fmt.Println("failed to load the bag:", err)
+		os.Exit(1)
+	}
+	if *seed != 0 {
+		cfg.rng = rand.New(rand.NewSource(*seed))
+	}
 
-		scanner.Scan()
-		// input := strings.TrimSpace(scanner.Text())
-		input := strings.Fields(scanner.Text())
+	// Инициализируем кэш с интервалом 45 секунд и, если задан каталог, с диском
+	cacheOpts := []pokecache.Option{
+		pokecache.WithMaxEntries(*cacheMaxEntries),
+		pokecache.WithMaxBytes(*cacheMaxBytes),
+	}
+	if *cacheDir != "" {
+		cacheOpts = append(cacheOpts, pokecache.WithDisk(*cacheDir, *cacheTTL))
+	}
+	cfg.cache = pokecache.NewCache(45*time.Second, cacheOpts...)
+	cfg.client = pokeapi.NewClient(pokeapi.BaseURL, 10*time.Second, cfg.cache)
+	if *offlineDir != "" {
+		cfg.client = pokeapi.NewOfflineClient(*offlineDir)
+		fmt.Printf("...OFFLINE MODE: reading data from %s...\n", *offlineDir)
+	}
 
-		if input == nil {
-			continue
-		}
-		// fmt.Println(input[0])
-		// fmt.Println(input[1])
-		if len(input) == 1 {
-			input = append(input, "")
+	if cfg.version != "" {
+		if _, err := cfg.client.GetVersion(cfg.version); err != nil {
+			fmt.Printf("unknown game version %s: %v\n", cfg.version, err)
+			shutdown(&cfg)
+			os.Exit(2)
 		}
+	}
 
-		inputCommand, ok := commands[input[0]]
-		if !ok {
-			fmt.Println("Unknown command")
-			continue
-		}
+	commands := getCommands()
 
-		err := inputCommand.callback(&pageConfig, input[1])
+	ok := true
+	switch {
+	case *script != "":
+		// Команды через ";" в аргументе -c
+		ok = runScript(&cfg, commands, newScannerSource(strings.NewReader(strings.ReplaceAll(*script, ";", "\n"))), "")
+	case *scriptFile != "":
+		file, err := os.Open(*scriptFile)
 		if err != nil {
-			fmt.Println("something goes wrong after callback func")
-			continue
+			fmt.Fprintln(os.Stderr, err)
+			shutdown(&cfg)
+			os.Exit(1)
 		}
+		ok = runScript(&cfg, commands, newScannerSource(file), "")
+		file.Close()
+	case isTerminal(os.Stdin):
+		editor := readline.New(os.Stdin, os.Stdout, *historyPath)
+		editor.Complete = completer(&cfg, commands)
+		// В REPL ошибки команд уже показаны; на код выхода они влияют только в пакетном режиме
+		runScript(&cfg, commands, editor, "Pokedex > ")
+	default:
+		// stdin не терминал (например, pipe) - приглашение не печатаем
+		ok = runScript(&cfg, commands, newScannerSource(os.Stdin), "")
+	}
+
+	shutdown(&cfg)
+	if !ok {
+		os.Exit(1)
 	}
-
 }
@@ -3,40 +3,41 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
+	"io/fs"
+	"math/rand"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/IdrisovMarat/pokemon/internal/inventory"
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
 	"github.com/IdrisovMarat/pokemon/internal/pokecache"
 )
 
-func TestProcessCachedData(t *testing.T) {
+func TestShowLocationPage(t *testing.T) {
 	cfg := &config{offset: 0, limit: 20}
-	testData := locationJson{
+	testData := pokeapi.LocationAreaList{
 		Count:    100,
 		Next:     stringPtr("next-url"),
 		Previous: stringPtr("prev-url"),
-		Results: []Results{
+		Results: []pokeapi.NamedResource{
 			{Name: "location1", URL: "url1"},
 			{Name: "location2", URL: "url2"},
 		},
 	}
 
-	data, err := json.Marshal(testData)
-	if err != nil {
-		t.Fatalf("Failed to marshal test data: %v", err)
-	}
-
 	// Capture stdout
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err = processCachedData(data, cfg)
+	err := showLocationPage(testData, cfg)
 
 	w.Close()
 	os.Stdout = oldStdout
@@ -46,7 +47,7 @@ func TestProcessCachedData(t *testing.T) {
 	output := buf.String()
 
 	if err != nil {
-		t.Errorf("processCachedData returned error: %v", err)
+		t.Errorf("showLocationPage returned error: %v", err)
 	}
 
 	if cfg.next == nil || *cfg.next != "next-url" {
@@ -98,26 +99,20 @@ func TestCommandHelp(t *testing.T) {
 func TestCommandMapWithCache(t *testing.T) {
 	// Setup test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		response := locationJson{
+		response := pokeapi.LocationAreaList{
 			Count:    100,
 			Next:     stringPtr("next-page"),
 			Previous: nil,
-			Results:  []Results{{Name: "test-location", URL: "test-url"}},
+			Results:  []pokeapi.NamedResource{{Name: "test-location", URL: "test-url"}},
 		}
 		json.NewEncoder(w).Encode(response)
 	}))
 	defer server.Close()
 
-	// Override baseUrl for testing
-	originalBaseUrl := baseUrl
-	defer func() { baseUrl = originalBaseUrl }()
-	baseUrl = server.URL + "/"
-
-	// Initialize cache
-	cache = pokecache.NewCache(1 * time.Minute)
-	defer cache.Stop()
-
-	cfg := &config{offset: 0, limit: 20}
+	// Inject a mock cache and point the client at the test server
+	cache := NewMockCache()
+	cfg := &config{offset: 0, limit: 20, cache: cache}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, cache)
 
 	// First call - should hit the server
 	err := commandMap(cfg)
@@ -135,25 +130,18 @@ func TestCommandMapWithCache(t *testing.T) {
 func TestCommandMapb(t *testing.T) {
 	// Setup test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		response := locationJson{
+		response := pokeapi.LocationAreaList{
 			Count:    100,
 			Next:     stringPtr("next-page"),
 			Previous: stringPtr("prev-page"),
-			Results:  []Results{{Name: "prev-location", URL: "prev-url"}},
+			Results:  []pokeapi.NamedResource{{Name: "prev-location", URL: "prev-url"}},
 		}
 		json.NewEncoder(w).Encode(response)
 	}))
 	defer server.Close()
 
-	// Override baseUrl for testing
-	originalBaseUrl := baseUrl
-	defer func() { baseUrl = originalBaseUrl }()
-	baseUrl = server.URL + "/"
-
-	cache = pokecache.NewCache(1 * time.Minute)
-	defer cache.Stop()
-
 	cfg := &config{offset: 40, limit: 20} // Start from offset 40
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, NewMockCache())
 
 	err := commandMapb(cfg)
 	if err != nil {
@@ -199,15 +187,8 @@ func TestCommandMapHTTPError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Override baseUrl for testing
-	originalBaseUrl := baseUrl
-	defer func() { baseUrl = originalBaseUrl }()
-	baseUrl = server.URL + "/"
-
-	cache = pokecache.NewCache(1 * time.Minute)
-	defer cache.Stop()
-
 	cfg := &config{offset: 0, limit: 20}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, NewMockCache())
 
 	err := commandMap(cfg)
 	if err == nil {
@@ -271,3 +252,523 @@ func TestConfigInitialization(t *testing.T) {
 		t.Errorf("Expected initial previous to be nil, got %v", pageConfig.previous)
 	}
 }
+
+func TestCommandInspect(t *testing.T) {
+	pokedex := pokecache.NewPokedex()
+	pokedex.Add(pokecache.Pokemonmain{
+		Name:           "pidgey",
+		Height:         3,
+		Weight:         18,
+		BaseExperience: 50,
+		Stats:          []pokecache.Stat{{Name: "hp", BaseStat: 40}},
+		Types:          []string{"normal", "flying"},
+	})
+
+	cfg := &config{pokedex: pokedex}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := commandInspect(cfg, "pidgey")
+	errMissing := commandInspect(cfg, "mewtwo")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	if err != nil {
+		t.Errorf("commandInspect returned error: %v", err)
+	}
+	if errMissing == nil || errMissing.Error() != "you have not caught mewtwo" {
+		t.Errorf("Expected an error for an uncaught pokemon, got %v", errMissing)
+	}
+
+	expectedStrings := []string{"Name: pidgey", "Height: 3", "Weight: 18", "-hp: 40", "- flying"}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain '%s', got: %s", expected, output)
+		}
+	}
+}
+
+func TestCommandPokedex(t *testing.T) {
+	pokedex := pokecache.NewPokedex()
+	now := time.Now()
+	pokedex.Add(pokecache.Pokemonmain{Name: "charmander", BaseExperience: 62, Types: []string{"fire"}, CreatedAt: now})
+	pokedex.Add(pokecache.Pokemonmain{Name: "vulpix", BaseExperience: 60, Types: []string{"fire"}, CreatedAt: now.Add(-time.Minute)})
+	pokedex.Add(pokecache.Pokemonmain{Name: "bulbasaur", BaseExperience: 64, Types: []string{"grass", "poison"}, CreatedAt: now.Add(time.Minute)})
+
+	cases := []struct {
+		args     []string
+		expected []string
+	}{
+		{args: nil, expected: []string{"bulbasaur", "charmander", "vulpix"}},
+		{args: []string{"--sort=caught"}, expected: []string{"vulpix", "charmander", "bulbasaur"}},
+		{args: []string{"--sort=exp"}, expected: []string{"vulpix", "charmander", "bulbasaur"}},
+		{args: []string{"--type=fire"}, expected: []string{"charmander", "vulpix"}},
+	}
+
+	for _, c := range cases {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := commandPokedex(&config{pokedex: pokedex}, c.args...)
+
+		w.Close()
+		os.Stdout = oldStdout
+
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		output := buf.String()
+
+		if err != nil {
+			t.Errorf("commandPokedex(%v) returned error: %v", c.args, err)
+		}
+
+		last := -1
+		for _, name := range c.expected {
+			idx := strings.Index(output, name)
+			if idx <= last {
+				t.Errorf("commandPokedex(%v): expected %s in order %v, got: %s", c.args, name, c.expected, output)
+			}
+			last = idx
+		}
+		if len(c.expected) == 2 && strings.Contains(output, "bulbasaur") {
+			t.Errorf("commandPokedex(%v): expected bulbasaur to be filtered out, got: %s", c.args, output)
+		}
+	}
+
+	if err := commandPokedex(&config{pokedex: pokedex}, "--sort=height"); err == nil {
+		t.Error("Expected error for unknown sort order")
+	}
+}
+
+func TestCommandSaveLoad(t *testing.T) {
+	cfg := &config{
+		pokedex:     pokecache.NewPokedex(),
+		inventory:   inventory.NewStarter(),
+		pokedexPath: filepath.Join(t.TempDir(), "pokedex.json"),
+	}
+	cfg.pokedex.Add(pokecache.Pokemonmain{Name: "eevee", BaseExperience: 65})
+	cfg.inventory.Use("master-ball")
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+
+	errSave := commandSave(cfg)
+	cfg.pokedex, cfg.inventory = pokecache.NewPokedex(), inventory.NewStarter()
+	errLoad := commandLoad(cfg)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if errSave != nil || errLoad != nil {
+		t.Fatalf("save/load returned errors: %v, %v", errSave, errLoad)
+	}
+	if _, ok := cfg.pokedex.Get("eevee"); !ok {
+		t.Errorf("Expected eevee to be restored from %s", cfg.pokedexPath)
+	}
+	if got := cfg.inventory.Count("master-ball"); got != 0 {
+		t.Errorf("Expected the spent Master Ball to stay spent, got %d", got)
+	}
+
+	// При запуске стартовая сумка выдается, только если сохраненной нет
+	bag, err := loadBag(cfg.pokedexPath)
+	if err != nil || bag.Count("master-ball") != 0 {
+		t.Errorf("Expected the saved bag at start, got %v (%v)", bag, err)
+	}
+	bag, err = loadBag(filepath.Join(t.TempDir(), "pokedex.json"))
+	if err != nil || bag.Count("master-ball") != 1 {
+		t.Errorf("Expected a starter bag without a save, got %v (%v)", bag, err)
+	}
+}
+
+func TestCommandLoadMissingFileKeepsPokedex(t *testing.T) {
+	cfg := &config{pokedex: pokecache.NewPokedex(), inventory: inventory.NewStarter()}
+	cfg.pokedex.Add(pokecache.Pokemonmain{Name: "eevee"})
+	pokedex := cfg.pokedex
+
+	err := commandLoad(cfg, filepath.Join(t.TempDir(), "typo", "pokedex.json"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist for a missing file, got %v", err)
+	}
+	if cfg.pokedex != pokedex || cfg.pokedex.Len() != 1 {
+		t.Errorf("Expected the current Pokedex to stay, got %d pokemons", cfg.pokedex.Len())
+	}
+}
+
+func TestCatchDoesNotChangeMapEndpoint(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/pokemon/"):
+			json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: "pidgey", BaseExperience: 50})
+		case strings.HasPrefix(r.URL.Path, "/pokemon-species/"):
+			json.NewEncoder(w).Encode(pokeapi.PokemonSpecies{Name: "pidgey", CaptureRate: 255})
+		default:
+			json.NewEncoder(w).Encode(pokeapi.LocationAreaList{Results: []pokeapi.NamedResource{{Name: "area"}}})
+		}
+	}))
+	defer server.Close()
+
+	client := pokeapi.NewClient(server.URL+"/", time.Second, NewMockCache())
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+
+	errCatch := commandCatch(&config{client: client, sandbox: true, pokedex: pokecache.NewPokedex(), inventory: inventory.NewStarter()}, "pidgey")
+	errMap := commandMap(&config{offset: 0, limit: 20, client: client})
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if errCatch != nil || errMap != nil {
+		t.Fatalf("unexpected errors: %v, %v", errCatch, errMap)
+	}
+
+	expected := []string{"/pokemon/pidgey", "/pokemon-species/pidgey", "/location-area/"}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected requests %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("Expected request %d to be %s, got %s", i, expected[i], paths[i])
+		}
+	}
+}
+
+func TestCommandMapUsesInjectedCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %s, expected cached data", r.URL)
+	}))
+	defer server.Close()
+
+	cache := NewMockCache()
+	data, _ := json.Marshal(pokeapi.LocationAreaList{Results: []pokeapi.NamedResource{{Name: "cached-location"}}})
+	cache.Add(server.URL+"/location-area/?offset=0&limit=20", data)
+
+	cfg := &config{offset: 0, limit: 20, cache: cache}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, cache)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := commandMap(cfg)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("commandMap returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "cached-location") {
+		t.Errorf("Expected cached location in output, got: %s", buf.String())
+	}
+}
+
+func TestCommandCache(t *testing.T) {
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Stop()
+	cache.Add("https://example.com/a", []byte("testdata"))
+	cache.Get("https://example.com/a")
+	cache.Get("https://example.com/missing")
+
+	cfg := &config{cache: cache}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	errStats := commandCache(cfg)
+	errKeys := commandCache(cfg, "keys")
+	errDrop := commandCache(cfg, "drop", "https://example.com/a")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	if errStats != nil || errKeys != nil || errDrop != nil {
+		t.Fatalf("commandCache returned errors: %v, %v, %v", errStats, errKeys, errDrop)
+	}
+
+	expectedStrings := []string{"hits:      1", "misses:    1", "hit rate:  50.0%", "https://example.com/a (age", "8 bytes", "dropped"}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain '%s', got: %s", expected, output)
+		}
+	}
+
+	if _, ok := cache.Get("https://example.com/a"); ok {
+		t.Error("Expected key to be dropped")
+	}
+
+	if err := commandCache(&config{cache: NewMockCache()}); err == nil {
+		t.Error("Expected error for a cache without inspection support")
+	}
+}
+
+func TestCatchLegacyModeSkipsSpecies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon/pidgey" {
+			t.Errorf("Unexpected request to %s in legacy mode", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: "pidgey", BaseExperience: 50})
+	}))
+	defer server.Close()
+
+	cfg := &config{catchMode: catchModeLegacy, sandbox: true, pokedex: pokecache.NewPokedex(), inventory: inventory.NewStarter()}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := commandCatch(cfg, "pidgey")
+	errBall := commandCatch(cfg, "pidgey", "--ball=master")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	if err != nil {
+		t.Fatalf("commandCatch returned error: %v", err)
+	}
+	if errBall == nil || cfg.inventory.Count("master-ball") != 1 {
+		t.Errorf("Expected --ball to be refused in legacy mode without spending it, got %v", errBall)
+	}
+	if strings.Contains(output, "wobble") {
+		t.Errorf("Expected no shake checks in legacy mode, got: %s", output)
+	}
+	if !strings.Contains(output, "was caught!") && !strings.Contains(output, "escaped!") {
+		t.Errorf("Expected a catch outcome, got: %s", output)
+	}
+}
+
+func TestCatchWithBall(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/pokemon/"):
+			json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: "mewtwo", BaseExperience: 340})
+		case strings.HasPrefix(r.URL.Path, "/pokemon-species/"):
+			json.NewEncoder(w).Encode(pokeapi.PokemonSpecies{Name: "mewtwo", CaptureRate: 3})
+		}
+	}))
+	defer server.Close()
+
+	inv := inventory.New()
+	inv.Add("master-ball", 1)
+	cfg := &config{sandbox: true, pokedex: pokecache.NewPokedex(), inventory: inv}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	errMaster := commandCatch(cfg, "mewtwo", "--ball=master")
+	errEmpty := commandCatch(cfg, "mewtwo", "--ball=master")
+	errNoPoke := commandCatch(cfg, "mewtwo")
+	errUnknown := commandCatch(cfg, "mewtwo", "--ball=heavy")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	if errMaster != nil {
+		t.Fatalf("commandCatch returned error: %v", errMaster)
+	}
+	if errEmpty == nil || errEmpty.Error() != "you have no Master Balls left" {
+		t.Errorf("Expected an error for an empty Master Ball pocket, got %v", errEmpty)
+	}
+	if errNoPoke == nil || errNoPoke.Error() != "you have no Poké Balls left" {
+		t.Errorf("Expected an error for an empty Poké Ball pocket, got %v", errNoPoke)
+	}
+	if errUnknown == nil {
+		t.Error("Expected error for an unknown ball")
+	}
+
+	expectedStrings := []string{"Throwing a Master Ball at mewtwo", "mewtwo was caught!"}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain '%s', got: %s", expected, output)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected only the first throw to hit the API, got %d requests", requests)
+	}
+	if _, ok := cfg.pokedex.Get("mewtwo"); !ok {
+		t.Error("Expected mewtwo in the Pokedex")
+	}
+}
+
+func TestCatchKeepsBallWhenSpeciesFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/pokemon-species/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: "mewtwo", BaseExperience: 340})
+	}))
+	defer server.Close()
+
+	inv := inventory.New()
+	inv.Add("master-ball", 1)
+	cfg := &config{sandbox: true, pokedex: pokecache.NewPokedex(), inventory: inv}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+	if err := commandCatch(cfg, "mewtwo", "--ball=master"); err == nil {
+		t.Fatal("Expected error when pokemon-species cannot be loaded")
+	}
+	if got := cfg.inventory.Count("master-ball"); got != 1 {
+		t.Errorf("Expected the Master Ball to stay in the bag, got %d", got)
+	}
+}
+
+func TestCatchPokemonIsDeterministicForSeed(t *testing.T) {
+	a := rand.New(rand.NewSource(1))
+	b := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		if CatchPokemon(a, 64) != CatchPokemon(b, 64) {
+			t.Fatalf("Catch %d: expected the same outcome for the same seed", i)
+		}
+	}
+}
+
+func TestCatchSequenceIsReproducibleWithSessionSeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/pokemon/"):
+			json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: "abra", BaseExperience: 62})
+		case strings.HasPrefix(r.URL.Path, "/pokemon-species/"):
+			json.NewEncoder(w).Encode(pokeapi.PokemonSpecies{Name: "abra", CaptureRate: 200})
+		}
+	}))
+	defer server.Close()
+
+	play := func() string {
+		cfg := &config{sandbox: true, pokedex: pokecache.NewPokedex(), inventory: inventory.NewStarter(), rng: rand.New(rand.NewSource(7))}
+		cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		for i := 0; i < 10; i++ {
+			commandCatch(cfg, "abra")
+		}
+
+		w.Close()
+		os.Stdout = oldStdout
+
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		return buf.String()
+	}
+
+	first, second := play(), play()
+	if first != second {
+		t.Errorf("Expected the same catch outcomes for the same seed:\n%s\n---\n%s", first, second)
+	}
+	if !strings.Contains(first, "was caught!") || !strings.Contains(first, "escaped!") {
+		t.Errorf("Expected a mix of outcomes in 10 throws, got: %s", first)
+	}
+}
+
+func TestPokedexSeenAndCaughtCounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/pokemon-species/":
+			json.NewEncoder(w).Encode(pokeapi.NamedResourceList{Count: 1025})
+		case strings.HasPrefix(r.URL.Path, "/pokemon/"):
+			json.NewEncoder(w).Encode(pokeapi.Pokemon{Name: "mewtwo", BaseExperience: 340})
+		case strings.HasPrefix(r.URL.Path, "/pokemon-species/"):
+			json.NewEncoder(w).Encode(pokeapi.PokemonSpecies{Name: "mewtwo", CaptureRate: 3})
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config{sandbox: true, pokedex: pokecache.NewPokedex(), inventory: inventory.NewStarter(), rng: rand.New(rand.NewSource(1))}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+	cfg.pokedex.Add(pokecache.Pokemonmain{Name: "pikachu"})
+	cfg.pokedex.Add(pokecache.Pokemonmain{Name: "pikachu"})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	// У mewtwo capture_rate 3, с этим seed Poké Ball не ловит его
+	errCatch := commandCatch(cfg, "mewtwo")
+	errPokedex := commandPokedex(cfg)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	if errCatch != nil || errPokedex != nil {
+		t.Fatalf("Unexpected errors: %v, %v", errCatch, errPokedex)
+	}
+	if !strings.Contains(output, "mewtwo escaped!") {
+		t.Fatalf("Expected mewtwo to escape, got: %s", output)
+	}
+	if !strings.Contains(output, "Seen: 2/1025, caught: 1/1025") {
+		t.Errorf("Expected seen/caught counts, got: %s", output)
+	}
+}
+
+func TestPokedexProgressRemembersFailedLookup(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := &config{pokedex: pokecache.NewPokedex()}
+	cfg.client = pokeapi.NewClient(server.URL+"/", time.Second, nil)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	commandPokedex(cfg)
+	commandPokedex(cfg)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	if requests != 1 {
+		t.Errorf("Expected the species count to be requested once per session, got %d requests", requests)
+	}
+	if strings.Count(buf.String(), "Seen: 0, caught: 0") != 2 {
+		t.Errorf("Expected progress without totals, got: %s", buf.String())
+	}
+}
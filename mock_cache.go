@@ -2,6 +2,8 @@ package main
 
 import (
 	"sync"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
 )
 
 // MockCache для тестирования
@@ -10,6 +12,8 @@ type MockCache struct {
 	mu   sync.RWMutex
 }
 
+var _ pokecache.Cache = (*MockCache)(nil)
+
 func NewMockCache() *MockCache {
 	return &MockCache{
 		data: make(map[string][]byte),
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/IdrisovMarat/pokemon/internal/encounter"
+	"github.com/IdrisovMarat/pokemon/internal/readline"
+)
+
+// lineSource - источник строк для runScript
+type lineSource interface {
+	ReadLine(prompt string) (string, error)
+}
+
+// scannerSource читает строки из файла, аргумента -c или pipe
+type scannerSource struct {
+	scanner *bufio.Scanner
+}
+
+func newScannerSource(r io.Reader) *scannerSource {
+	return &scannerSource{scanner: bufio.NewScanner(r)}
+}
+
+func (s *scannerSource) ReadLine(prompt string) (string, error) {
+	if !s.scanner.Scan() {
+		if err := s.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return s.scanner.Text(), nil
+}
+
+// runLine выполняет одну строку ввода как команду
+func runLine(cfg *config, commands map[string]cliCommand, line string) error {
+	input := strings.Fields(line)
+	if len(input) == 0 {
+		return nil
+	}
+
+	inputCommand, ok := commands[input[0]]
+	if !ok {
+		return fmt.Errorf("unknown command: %s", input[0])
+	}
+
+	return inputCommand.callback(cfg, input[1:]...)
+}
+
+// runScript выполняет команды из src по одной на строку до EOF или exit.
+// Приглашение prompt печатается только в интерактивном режиме (непустой prompt).
+// Ошибки команд не прерывают ввод, строки, начинающиеся с #, считаются комментариями.
+// Возвращает false, если хотя бы одна команда завершилась ошибкой
+// (main превращает это в ненулевой код выхода только для -c, -f и pipe)
+func runScript(cfg *config, commands map[string]cliCommand, src lineSource, prompt string) bool {
+	interactive := prompt != ""
+	ok := true
+
+	for {
+		if interactive {
+			fmt.Println()
+		}
+
+		line, err := src.ReadLine(prompt)
+		if errors.Is(err, io.EOF) || errors.Is(err, readline.ErrInterrupt) {
+			if interactive {
+				fmt.Println()
+			}
+			return ok
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error reading input:", err)
+			return false
+		}
+
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		err = runLine(cfg, commands, line)
+		if errors.Is(err, errExit) {
+			return ok
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			ok = false
+		}
+	}
+}
+
+// sessionNames - имена, встреченные за сессию в выводе map и explore.
+// Используются для автодополнения аргументов
+type sessionNames struct {
+	areas   map[string]bool
+	pokemon map[string]bool
+}
+
+func (n *sessionNames) addArea(name string) {
+	if n.areas == nil {
+		n.areas = make(map[string]bool)
+	}
+	n.areas[name] = true
+}
+
+func (n *sessionNames) addPokemon(name string) {
+	if n.pokemon == nil {
+		n.pokemon = make(map[string]bool)
+	}
+	n.pokemon[name] = true
+}
+
+func keys(set map[string]bool) []string {
+	result := make([]string, 0, len(set))
+	for k := range set {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// completer дополняет имена команд, а в аргументах - имена локаций и покемонов
+func completer(cfg *config, commands map[string]cliCommand) readline.Completer {
+	return func(line string) []string {
+		words := strings.Fields(line)
+		if len(words) == 0 || len(words) == 1 && !strings.HasSuffix(line, " ") {
+			names := make([]string, 0, len(commands))
+			for name := range commands {
+				names = append(names, name)
+			}
+			return names
+		}
+
+		var caught []string
+		if cfg.pokedex != nil {
+			// Имена видов без повторов и прозвища экземпляров
+			for _, species := range cfg.pokedex.Species() {
+				caught = append(caught, species.Name)
+			}
+			for _, pokemon := range cfg.pokedex.List() {
+				if pokemon.Nickname != "" {
+					caught = append(caught, pokemon.Nickname)
+				}
+			}
+		}
+
+		switch words[0] {
+		case "explore", "goto":
+			return keys(cfg.seen.areas)
+		case "catch":
+			if cfg.area != nil && !cfg.sandbox {
+				var names []string
+				for _, pe := range cfg.area.PokemonEncounters {
+					if encounter.Appears(pe, cfg.version) {
+						names = append(names, pe.Pokemon.Name)
+					}
+				}
+				return names
+			}
+			return keys(cfg.seen.pokemon)
+		case "inspect", "battle", "nickname", "evolve", "deposit", "withdraw", "swap", "reorder":
+			return caught
+		default:
+			return append(append(keys(cfg.seen.areas), keys(cfg.seen.pokemon)...), caught...)
+		}
+	}
+}
+
+// isTerminal сообщает, подключен ли файл к терминалу
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/IdrisovMarat/pokemon/internal/pokeapi"
+	"github.com/IdrisovMarat/pokemon/internal/pokecache"
+)
+
+func TestRunScript(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		ok       bool
+		expected []string
+		missing  []string
+	}{
+		{
+			name:     "runs every command until EOF",
+			input:    "help\n\n# comment\npokedex\n",
+			ok:       true,
+			expected: []string{"Welcome", "Your Pokedex is empty"},
+		},
+		{
+			name:     "reports failing commands",
+			input:    "bogus\npokedex\n",
+			ok:       false,
+			expected: []string{"Your Pokedex is empty"},
+		},
+		{
+			name:    "reports a missing pokemon",
+			input:   "inspect mewtwo\nnickname mewtwo x\nevolve mewtwo\n",
+			ok:      false,
+			missing: []string{"Name:"},
+		},
+		{
+			name:     "stops at exit",
+			input:    "exit\nhelp\n",
+			ok:       true,
+			expected: []string{"Goodbye"},
+			missing:  []string{"Welcome"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := &config{pokedex: pokecache.NewPokedex()}
+
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stdout, os.Stderr = w, w
+
+			ok := runScript(cfg, getCommands(), newScannerSource(strings.NewReader(c.input)), "")
+
+			w.Close()
+			os.Stdout, os.Stderr = oldStdout, oldStderr
+
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			if ok != c.ok {
+				t.Errorf("Expected ok=%v, got %v", c.ok, ok)
+			}
+			if strings.Contains(output, "Pokedex >") {
+				t.Errorf("Expected no prompt in batch mode, got: %s", output)
+			}
+			for _, expected := range c.expected {
+				if !strings.Contains(output, expected) {
+					t.Errorf("Expected output to contain '%s', got: %s", expected, output)
+				}
+			}
+			for _, missing := range c.missing {
+				if strings.Contains(output, missing) {
+					t.Errorf("Expected output not to contain '%s', got: %s", missing, output)
+				}
+			}
+		})
+	}
+}
+
+func TestCompleter(t *testing.T) {
+	cfg := &config{pokedex: pokecache.NewPokedex()}
+	cfg.pokedex.Add(pokecache.Pokemonmain{Name: "pikachu"})
+	cfg.seen.addArea("canalave-city-area")
+	cfg.seen.addPokemon("tentacool")
+
+	complete := completer(cfg, getCommands())
+
+	cases := []struct {
+		line     string
+		expected string
+		missing  string
+	}{
+		{line: "ex", expected: "explore"},
+		{line: "explore ", expected: "canalave-city-area", missing: "tentacool"},
+		{line: "catch t", expected: "tentacool", missing: "canalave-city-area"},
+		{line: "inspect ", expected: "pikachu", missing: "tentacool"},
+		{line: "goto ", expected: "canalave-city-area", missing: "tentacool"},
+	}
+
+	for _, c := range cases {
+		candidates := strings.Join(complete(c.line), " ")
+		if !strings.Contains(candidates, c.expected) {
+			t.Errorf("complete(%q): expected %s in %v", c.line, c.expected, candidates)
+		}
+		if c.missing != "" && strings.Contains(candidates, c.missing) {
+			t.Errorf("complete(%q): expected no %s in %v", c.line, c.missing, candidates)
+		}
+	}
+
+	// В текущей локации catch дополняет только ее покемонов
+	area := pokeapi.LocationArea{Name: "canalave-city-area"}
+	area.PokemonEncounters = append(area.PokemonEncounters, pokeapi.PokemonEncounter{Pokemon: pokeapi.NamedResource{Name: "wingull"}})
+	cfg.area = &area
+	candidates := strings.Join(complete("catch "), " ")
+	if !strings.Contains(candidates, "wingull") || strings.Contains(candidates, "tentacool") {
+		t.Errorf("complete(\"catch \"): expected only pokemons of the area, got %v", candidates)
+	}
+}